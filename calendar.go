@@ -0,0 +1,322 @@
+package strftime
+
+import "time"
+
+// A Calendar converts times to and from the dates of a calendar system.
+// Months and days are numbered from 1, in the order they occur in the year.
+type Calendar interface {
+	// Date returns the year, month, and day in which t occurs.
+	Date(t time.Time) (year, month, day int)
+	// Time returns midnight UTC of the given date.
+	Time(year, month, day int) time.Time
+	// MonthsIn returns the number of months in the year.
+	MonthsIn(year int) int
+	// DaysIn returns the number of days in the month of the year.
+	DaysIn(year, month int) int
+	// MonthName returns the full or abbreviated name of the month of the year.
+	// Names can vary with the year (e.g. leap months) over a cycle of at most 19 years.
+	MonthName(year, month int, abbr bool) string
+}
+
+// Calendars implemented by this package.
+// The non-Gregorian calendars are arithmetic (tabular) calendars,
+// which may differ by a day or so from observational calendars.
+var (
+	Gregorian    Calendar = gregorian{}    // proleptic Gregorian calendar
+	Buddhist     Calendar = buddhist{}     // Thai solar calendar (Buddhist Era)
+	SolarHijri   Calendar = solarHijri{}   // Persian calendar, 33-year arithmetic cycle
+	IslamicCivil Calendar = islamicCivil{} // tabular Islamic calendar, Friday epoch
+	Hebrew       Calendar = hebrew{}       // arithmetic Hebrew calendar, months from Tishri
+)
+
+type gregorian struct{}
+
+func (gregorian) Date(t time.Time) (year, month, day int) {
+	y, m, d := t.Date()
+	return y, int(m), d
+}
+
+func (gregorian) Time(year, month, day int) time.Time {
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func (gregorian) MonthsIn(year int) int {
+	return 12
+}
+
+func (gregorian) DaysIn(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (gregorian) MonthName(year, month int, abbr bool) string {
+	if abbr {
+		return shortMonthNames[month-1]
+	}
+	return longMonthNames[month-1]
+}
+
+// The Buddhist Era starts 543 years before the Common Era.
+const buddhistOffset = 543
+
+type buddhist struct{ gregorian }
+
+func (c buddhist) Date(t time.Time) (year, month, day int) {
+	year, month, day = c.gregorian.Date(t)
+	return year + buddhistOffset, month, day
+}
+
+func (c buddhist) Time(year, month, day int) time.Time {
+	return c.gregorian.Time(year-buddhistOffset, month, day)
+}
+
+func (c buddhist) DaysIn(year, month int) int {
+	return c.gregorian.DaysIn(year-buddhistOffset, month)
+}
+
+// 1 Farvardin 1 AP, in days since 1970-01-01.
+const solarHijriEpoch = 1948320 - 2440588
+
+type solarHijri struct{}
+
+func (solarHijri) Date(t time.Time) (year, month, day int) {
+	days := epochDays(t) - solarHijriEpoch
+	year = 1 + floorDiv(33*days+3, 12053)
+	yday := days - solarHijriYear(year)
+	if yday < 216 {
+		month = yday / 31
+	} else {
+		month = (yday - 6) / 30
+	}
+	return year, month + 1, yday - solarHijriMonth(month+1) + 1
+}
+
+func (solarHijri) Time(year, month, day int) time.Time {
+	return dayTime(solarHijriEpoch + solarHijriYear(year) + solarHijriMonth(month) + day - 1)
+}
+
+func (solarHijri) MonthsIn(year int) int {
+	return 12
+}
+
+func (solarHijri) DaysIn(year, month int) int {
+	switch {
+	case month <= 6:
+		return 31
+	case month <= 11:
+		return 30
+	case floorMod(25*year+11, 33) < 8:
+		return 30
+	}
+	return 29
+}
+
+func (solarHijri) MonthName(year, month int, abbr bool) string {
+	return solarHijriMonthNames[month-1]
+}
+
+// solarHijriYear returns the days from the epoch to the start of the year.
+func solarHijriYear(year int) int {
+	return 365*(year-1) + floorDiv(8*year+21, 33)
+}
+
+// solarHijriMonth returns the days from the start of the year to the start of the month.
+func solarHijriMonth(month int) int {
+	if month <= 7 {
+		return 31 * (month - 1)
+	}
+	return 30*(month-1) + 6
+}
+
+var solarHijriMonthNames = []string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+
+// 1 Muharram 1 AH, in days since 1970-01-01.
+const islamicEpoch = 1948440 - 2440588
+
+type islamicCivil struct{}
+
+func (c islamicCivil) Date(t time.Time) (year, month, day int) {
+	days := epochDays(t) - islamicEpoch
+	year = floorDiv(30*days+10646, 10631)
+	month = floorDiv(11*(days-islamicDays(year, 1))+330, 325)
+	return year, month, days - islamicDays(year, month) + 1
+}
+
+func (islamicCivil) Time(year, month, day int) time.Time {
+	return dayTime(islamicEpoch + islamicDays(year, month) + day - 1)
+}
+
+func (islamicCivil) MonthsIn(year int) int {
+	return 12
+}
+
+func (islamicCivil) DaysIn(year, month int) int {
+	if month%2 == 1 || month == 12 && floorMod(14+11*year, 30) < 11 {
+		return 30
+	}
+	return 29
+}
+
+func (islamicCivil) MonthName(year, month int, abbr bool) string {
+	if abbr {
+		return shortIslamicMonthNames[month-1]
+	}
+	return longIslamicMonthNames[month-1]
+}
+
+// islamicDays returns the days from the epoch to the start of the month.
+func islamicDays(year, month int) int {
+	return 354*(year-1) + floorDiv(3+11*year, 30) + 29*(month-1) + month/2
+}
+
+var longIslamicMonthNames = []string{
+	"Muharram", "Safar", "Rabi' I", "Rabi' II", "Jumada I", "Jumada II",
+	"Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu'l-Qi'dah", "Dhu'l-Hijjah",
+}
+
+var shortIslamicMonthNames = []string{
+	"Muh.", "Saf.", "Rab. I", "Rab. II", "Jum. I", "Jum. II",
+	"Raj.", "Sha.", "Ram.", "Shaw.", "Dhu'l-Q.", "Dhu'l-H.",
+}
+
+// 1 Tishri 1 AM, in days since 1970-01-01.
+const hebrewEpoch = 347998 - 2440588
+
+type hebrew struct{}
+
+func (c hebrew) Date(t time.Time) (year, month, day int) {
+	days := epochDays(t)
+	year = floorDiv(4*(days-hebrewEpoch), 1461) + 1
+	for hebrewNewYear(year) > days {
+		year--
+	}
+	for hebrewNewYear(year+1) <= days {
+		year++
+	}
+	day = days - hebrewNewYear(year) + 1
+	for month = 1; day > c.DaysIn(year, month); month++ {
+		day -= c.DaysIn(year, month)
+	}
+	return year, month, day
+}
+
+func (c hebrew) Time(year, month, day int) time.Time {
+	days := hebrewNewYear(year)
+	for m := 1; m < month; m++ {
+		days += c.DaysIn(year, m)
+	}
+	return dayTime(days + day - 1)
+}
+
+func (hebrew) MonthsIn(year int) int {
+	if hebrewLeap(year) {
+		return 13
+	}
+	return 12
+}
+
+func (c hebrew) DaysIn(year, month int) int {
+	switch c.month(year, month) {
+	case "Heshvan":
+		if d := hebrewNewYear(year+1) - hebrewNewYear(year); d%10 == 5 {
+			return 30 // long Heshvan, in 355 and 385 day years
+		}
+		return 29
+	case "Kislev":
+		if d := hebrewNewYear(year+1) - hebrewNewYear(year); d%10 == 3 {
+			return 29 // short Kislev, in 353 and 383 day years
+		}
+		return 30
+	case "Tevet", "Adar", "Adar II", "Iyar", "Tamuz", "Elul":
+		return 29
+	}
+	return 30
+}
+
+func (c hebrew) MonthName(year, month int, abbr bool) string {
+	return c.month(year, month)
+}
+
+// month returns the name of the month of the year.
+// Leap years add Adar I before Adar, which is then called Adar II.
+func (hebrew) month(year, month int) string {
+	if hebrewLeap(year) {
+		switch {
+		case month == 6:
+			return "Adar I"
+		case month == 7:
+			return "Adar II"
+		case month > 7:
+			month--
+		}
+	}
+	return hebrewMonthNames[month-1]
+}
+
+var hebrewMonthNames = []string{
+	"Tishri", "Heshvan", "Kislev", "Tevet", "Shevat", "Adar",
+	"Nisan", "Iyar", "Sivan", "Tamuz", "Av", "Elul",
+}
+
+func hebrewLeap(year int) bool {
+	return floorMod(7*year+1, 19) < 7
+}
+
+// hebrewNewYear returns 1 Tishri of the year, in days since 1970-01-01.
+func hebrewNewYear(year int) int {
+	return hebrewEpoch + hebrewElapsed(year) + hebrewDelay(year)
+}
+
+// hebrewElapsed returns the days from the epoch to the molad of Tishri,
+// delayed when it falls on a Sunday, Wednesday, or Friday.
+func hebrewElapsed(year int) int {
+	months := floorDiv(235*year-234, 19)
+	parts := 12084 + 13753*months
+	days := 29*months + floorDiv(parts, 25920)
+	if floorMod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+// hebrewDelay returns the days the new year is delayed
+// to keep the length of the year, and the previous one, valid.
+func hebrewDelay(year int) int {
+	prev, this, next := hebrewElapsed(year-1), hebrewElapsed(year), hebrewElapsed(year+1)
+	switch {
+	case next-this == 356:
+		return 2
+	case this-prev == 382:
+		return 1
+	}
+	return 0
+}
+
+// epochDays returns the days since 1970-01-01 of the date of t.
+func epochDays(t time.Time) int {
+	days, _ := civilDays(t)
+	return int(days)
+}
+
+// dayTime returns midnight UTC of a day since 1970-01-01.
+func dayTime(days int) time.Time {
+	return time.Unix(int64(days)*86400, 0).UTC()
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+func floorMod(a, b int) int {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
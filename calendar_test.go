@@ -0,0 +1,164 @@
+package strftime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestCalendar_Date(t *testing.T) {
+	tests := []struct {
+		calendar strftime.Calendar
+		time     time.Time
+		want     string
+	}{
+		{strftime.Buddhist, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), "2567-05-01 May"},
+		{strftime.Buddhist, time.Date(1941, 1, 1, 0, 0, 0, 0, time.UTC), "2484-01-01 January"},
+
+		// Nowruz, and the Iranian revolution.
+		{strftime.SolarHijri, time.Date(622, 3, 21, 0, 0, 0, 0, time.UTC), "0001-01-01 Farvardin"},
+		{strftime.SolarHijri, time.Date(1975, 3, 21, 0, 0, 0, 0, time.UTC), "1354-01-01 Farvardin"},
+		{strftime.SolarHijri, time.Date(1979, 2, 11, 0, 0, 0, 0, time.UTC), "1357-11-22 Bahman"},
+		{strftime.SolarHijri, time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC), "1399-01-01 Farvardin"},
+		{strftime.SolarHijri, time.Date(2023, 3, 21, 0, 0, 0, 0, time.UTC), "1402-01-01 Farvardin"},
+		{strftime.SolarHijri, time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), "1403-01-01 Farvardin"},
+		{strftime.SolarHijri, time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC), "1403-12-30 Esfand"},
+		{strftime.SolarHijri, time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC), "1404-01-01 Farvardin"},
+
+		{strftime.IslamicCivil, time.Date(622, 7, 19, 0, 0, 0, 0, time.UTC), "0001-01-01 Muharram"},
+		{strftime.IslamicCivil, time.Date(1945, 11, 12, 0, 0, 0, 0, time.UTC), "1364-12-06 Dhu'l-Hijjah"},
+		{strftime.IslamicCivil, time.Date(2023, 7, 19, 0, 0, 0, 0, time.UTC), "1445-01-01 Muharram"},
+		{strftime.IslamicCivil, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), "1445-09-01 Ramadan"},
+		{strftime.IslamicCivil, time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC), "1445-12-30 Dhu'l-Hijjah"},
+
+		// Rosh Hashanah, Yom Kippur, Hanukkah, Purim, and Passover.
+		{strftime.Hebrew, time.Date(-3760, 9, 7, 0, 0, 0, 0, time.UTC), "0001-01-01 Tishri"},
+		{strftime.Hebrew, time.Date(2020, 9, 19, 0, 0, 0, 0, time.UTC), "5781-01-01 Tishri"},
+		{strftime.Hebrew, time.Date(2021, 9, 7, 0, 0, 0, 0, time.UTC), "5782-01-01 Tishri"},
+		{strftime.Hebrew, time.Date(2022, 9, 26, 0, 0, 0, 0, time.UTC), "5783-01-01 Tishri"},
+		{strftime.Hebrew, time.Date(2023, 3, 7, 0, 0, 0, 0, time.UTC), "5783-06-14 Adar"},
+		{strftime.Hebrew, time.Date(2023, 9, 16, 0, 0, 0, 0, time.UTC), "5784-01-01 Tishri"},
+		{strftime.Hebrew, time.Date(2023, 9, 25, 0, 0, 0, 0, time.UTC), "5784-01-10 Tishri"},
+		{strftime.Hebrew, time.Date(2023, 12, 8, 0, 0, 0, 0, time.UTC), "5784-03-25 Kislev"},
+		{strftime.Hebrew, time.Date(2024, 3, 24, 0, 0, 0, 0, time.UTC), "5784-07-14 Adar II"},
+		{strftime.Hebrew, time.Date(2024, 4, 23, 0, 0, 0, 0, time.UTC), "5784-08-15 Nisan"},
+		{strftime.Hebrew, time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC), "5785-01-01 Tishri"},
+	}
+
+	for _, test := range tests {
+		locale := &strftime.Locale{Calendar: test.calendar}
+		if got := locale.Format("%F %B", test.time); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.time, got, test.want)
+		}
+		if got, err := locale.Parse("%F %B", test.want); err != nil {
+			t.Errorf("Parse(%q) = %v", test.want, err)
+		} else if !got.Equal(test.time) {
+			t.Errorf("Parse(%q) = %v, want %v", test.want, got, test.time)
+		}
+	}
+}
+
+func TestCalendar_Parse(t *testing.T) {
+	calendars := []strftime.Calendar{
+		strftime.Gregorian,
+		strftime.Buddhist,
+		strftime.SolarHijri,
+		strftime.IslamicCivil,
+		strftime.Hebrew,
+	}
+	formats := []string{
+		"%Y-%m-%d",
+		"%e %B %Y",
+		"%b %d, %Y",
+		"%Y %j",
+		"%Y %B, the %:w%o %A",
+		"%Y %U %w",
+		"%Y %W %u",
+	}
+
+	base := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	for _, calendar := range calendars {
+		locale := &strftime.Locale{Calendar: calendar}
+		for d := 0; d < 4*366; d++ {
+			date := base.AddDate(0, 0, d)
+			for _, format := range formats {
+				value := locale.Format(format, date)
+				if got, err := locale.Parse(format, value); err != nil {
+					t.Fatalf("Parse(%q, %q) = %v", format, value, err)
+				} else if !got.Equal(date) {
+					t.Fatalf("Parse(%q, %q) = %v, want %v", format, value, got, date)
+				}
+			}
+		}
+	}
+}
+
+func TestCalendar_Week(t *testing.T) {
+	// Weeks count from the start of the calendar year, 5735-01-01 (Tuesday).
+	hebrew := &strftime.Locale{Calendar: strftime.Hebrew}
+	date := time.Date(1975, 1, 5, 0, 0, 0, 0, time.UTC)
+	for format, want := range map[string]string{
+		"%Y %U %w": "5735 16 0",
+		"%Y %W %u": "5735 15 7",
+	} {
+		if got := hebrew.Format(format, date); got != want {
+			t.Errorf("Format(%q) = %q, want %q", format, got, want)
+		}
+		if got, err := hebrew.Parse(format, want); err != nil {
+			t.Errorf("Parse(%q, %q) = %v", format, want, err)
+		} else if !got.Equal(date) {
+			t.Errorf("Parse(%q, %q) = %v, want %v", format, want, got, date)
+		}
+	}
+}
+
+// sabbatical is a calendar that renames December
+// in the last year of each 7-year cycle.
+type sabbatical struct{ strftime.Calendar }
+
+func (c sabbatical) MonthName(year, month int, abbr bool) string {
+	if month == 12 && year%7 == 6 {
+		return "Sabbatical December"
+	}
+	return c.Calendar.MonthName(year, month, abbr)
+}
+
+func TestCalendar_MonthName(t *testing.T) {
+	locale := &strftime.Locale{Calendar: sabbatical{strftime.Gregorian}}
+	want := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	// The name is matched before the year is known.
+	for _, format := range []string{"%B %d, %Y", "%Y %B %d"} {
+		value := locale.Format(format, want)
+		if got, err := locale.Parse(format, value); err != nil {
+			t.Errorf("Parse(%q, %q) = %v", format, value, err)
+		} else if !got.Equal(want) {
+			t.Errorf("Parse(%q, %q) = %v, want %v", format, value, got, want)
+		}
+	}
+	if got, err := locale.Parse("%B %d, %Y", "Sabbatical December 01, 2023"); err == nil {
+		t.Errorf("Parse() = %v", got)
+	}
+}
+
+func TestCalendar_Errors(t *testing.T) {
+	hebrew := &strftime.Locale{Calendar: strftime.Hebrew}
+	tests := []string{
+		"5783-13-01",      // not a leap year
+		"5784-02-30",      // short Heshvan
+		"5783 Adar I 01",  // not a leap year
+		"5785 Adar II 01", // not a leap year
+		"5784 Nissan 01",  // not a month name
+	}
+	for _, value := range tests[:2] {
+		if got, err := hebrew.Parse("%Y-%m-%d", value); err == nil {
+			t.Errorf("Parse(%q) = %v", value, got)
+		}
+	}
+	for _, value := range tests[2:] {
+		if got, err := hebrew.Parse("%Y %B %d", value); err == nil {
+			t.Errorf("Parse(%q) = %v", value, got)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package strftime
+
+import (
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+const nsPerDay = 86400e9
+
+// dayEpoch returns the day count of 1970-01-01 for a day count specifier.
+func dayEpoch(spec byte) int64 {
+	switch spec {
+	case 'J': // Julian Day Number
+		return 2440588
+	case 'K': // Modified Julian Day
+		return 40587
+	default: // OLE Automation date
+		return 25569
+	}
+}
+
+// civilDays returns the number of days since 1970-01-01
+// of the date of t, and the nanoseconds since midnight.
+func civilDays(t time.Time) (days, ns int64) {
+	y, m, d := t.Date()
+	days = time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+	h, mm, s := t.Clock()
+	ns = int64(h*3600+mm*60+s)*1e9 + int64(t.Nanosecond())
+	return days, ns
+}
+
+// appendDays appends the day count of t,
+// optionally with a decimal fraction of the day.
+// Julian and Modified Julian Days count Universal Time days;
+// spreadsheet serials count the days of the wall clock of t.
+func appendDays(dst []byte, t time.Time, spec byte, frac bool) []byte {
+	if spec != 'i' {
+		t = t.UTC()
+	}
+	days, ns := civilDays(t)
+	days += dayEpoch(spec)
+	if !frac {
+		return strconv.AppendInt(dst, days, 10)
+	}
+
+	// Julian Dates start at noon.
+	if spec == 'J' {
+		ns -= nsPerDay / 2
+		if ns < 0 {
+			days, ns = days-1, ns+nsPerDay
+		}
+	}
+	// OLE Automation dates carry the fraction of the day
+	// as a positive offset, even for negative serials.
+	if days < 0 && ns > 0 && spec != 'i' {
+		dst = append(dst, '-')
+		days, ns = -days-1, nsPerDay-ns
+	}
+	dst = strconv.AppendInt(dst, days, 10)
+	return appendFraction(dst, ns)
+}
+
+// appendFraction appends the shortest decimal fraction of a day
+// that rounds to ns nanoseconds.
+func appendFraction(dst []byte, ns int64) []byte {
+	for pow := uint64(10); ; pow *= 10 {
+		frac := mulDiv(uint64(ns), pow, nsPerDay)
+		if mulDiv(frac, nsPerDay, pow) == uint64(ns) {
+			// Zero pad the fraction, replacing the leading 1 with a dot.
+			i := len(dst)
+			dst = strconv.AppendUint(dst, pow+frac, 10)
+			dst[i] = '.'
+			return dst
+		}
+	}
+}
+
+// mulDiv returns a*b/c, rounded to nearest.
+func mulDiv(a, b, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	lo, carry := bits.Add64(lo, c/2, 0)
+	q, _ := bits.Div64(hi+carry, lo, c)
+	return q
+}
+
+// getdays parses a day count,
+// optionally with a decimal fraction of the day.
+func getdays(s string, spec byte, frac bool) (date time.Time, rest string, ok bool) {
+	neg := len(s) > 0 && s[0] == '-'
+	if neg {
+		s = s[1:]
+	}
+	n, rest, ok := getnum(s, 1, 12)
+	if !ok {
+		return time.Time{}, s, false
+	}
+
+	days, ns := int64(n), int64(0)
+	if frac && len(rest) > 1 && rest[0] == '.' && isDigit(rest, 1) {
+		var num, pow uint64 = 0, 1
+		var i int
+		for i = 1; isDigit(rest, i); i++ {
+			if pow < 1e15 {
+				num = num*10 + uint64(rest[i]-'0')
+				pow *= 10
+			}
+		}
+		ns = int64(mulDiv(num, nsPerDay, pow))
+		rest = rest[i:]
+	}
+
+	if neg {
+		days = -days
+		if ns > 0 && spec != 'i' {
+			days, ns = days-1, nsPerDay-ns
+		}
+	}
+	if frac && spec == 'J' {
+		ns += nsPerDay / 2
+	}
+	days += ns/nsPerDay - dayEpoch(spec)
+	ns %= nsPerDay
+
+	date = time.Unix(days*86400, ns).UTC()
+	return date, rest, true
+}
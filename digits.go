@@ -0,0 +1,92 @@
+package strftime
+
+import "strings"
+
+// Digits are the decimal digits (0..9) of a numbering system.
+type Digits [10]string
+
+// Built-in digits of some common numbering systems.
+var (
+	ArabicIndicDigits = DecimalDigits('٠') // ٠١٢٣٤٥٦٧٨٩
+	PersianDigits     = DecimalDigits('۰') // ۰۱۲۳۴۵۶۷۸۹ (Extended Arabic-Indic)
+	DevanagariDigits  = DecimalDigits('०') // ०१२३४५६७८९
+	BengaliDigits     = DecimalDigits('০') // ০১২৩৪৫৬৭৮৯
+	ThaiDigits        = DecimalDigits('๐') // ๐๑๒๓๔๕๖๗๘๙
+	MyanmarDigits     = DecimalDigits('၀') // ၀၁၂၃၄၅၆၇၈၉
+	FullwidthDigits   = DecimalDigits('０') // ０１２３４５６７８９
+	HanDigits         = Digits{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+)
+
+// DecimalDigits returns the digits of a Unicode decimal digit block,
+// given its zero digit.
+func DecimalDigits(zero rune) Digits {
+	var d Digits
+	for i := range d {
+		d[i] = string(zero + rune(i))
+	}
+	return d
+}
+
+// append appends s to dst, replacing ASCII digits.
+func (d *Digits) append(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		if b := s[i]; '0' <= b && b <= '9' && d[b-'0'] != "" {
+			dst = append(dst, d[b-'0']...)
+		} else {
+			dst = append(dst, b)
+		}
+	}
+	return dst
+}
+
+// ascii replaces the digits that start s with ASCII digits.
+// Signs and separators between digits are kept.
+// If any digits were replaced, it also returns the offset in s
+// of each byte of the replaced prefix, and of its end.
+func (d *Digits) ascii(s string) (string, []int) {
+	var buf strings.Builder
+	var offsets []int
+	var replaced bool
+	i := 0
+	for i < len(s) {
+		if strings.IndexByte("0123456789 +-.,:", s[i]) >= 0 {
+			buf.WriteByte(s[i])
+			offsets = append(offsets, i)
+			i++
+			continue
+		}
+		n := d.digit(s[i:])
+		if n < 0 {
+			break
+		}
+		buf.WriteByte('0' + byte(n))
+		offsets = append(offsets, i)
+		i += len(d[n])
+		replaced = true
+	}
+	if !replaced {
+		return s, nil
+	}
+	buf.WriteString(s[i:])
+	return buf.String(), append(offsets, i)
+}
+
+// digit returns the digit that starts s, or -1.
+func (d *Digits) digit(s string) int {
+	for n, digit := range d {
+		if digit != "" && strings.HasPrefix(s, digit) {
+			return n
+		}
+	}
+	return -1
+}
+
+// unascii maps a suffix of a string returned by ascii
+// to the corresponding suffix of the original string s.
+func unascii(s, ascii, rest string, offsets []int) string {
+	i := len(ascii) - len(rest)
+	if prefix := len(offsets) - 1; i > prefix {
+		return s[offsets[prefix]+i-prefix:]
+	}
+	return s[offsets[i]:]
+}
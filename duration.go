@@ -0,0 +1,294 @@
+package strftime
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatDuration returns a textual representation of the duration
+// formatted according to the format specification.
+//
+// The following specifiers are available:
+//
+//	%d  - Days
+//	%H  - Hours of the day, zero-padded (00..23)
+//	        %-H  no-padded (0..23)
+//	        %:H  total hours, zero-padded (00..)
+//	%M  - Minutes of the hour, zero-padded (00..59)
+//	        %-M  no-padded (0..59)
+//	        %:M  total minutes, zero-padded (00..)
+//	%S  - Seconds of the minute, zero-padded (00..59)
+//	        %-S  no-padded (0..59)
+//	%s  - Total seconds
+//	%Q  - Total milliseconds
+//	%L  - Millisecond of the second (000..999)
+//	%f  - Microsecond of the second (000000..999999)
+//	%N  - Nanosecond of the second (000000000..999999999)
+//	%+  - Sign (+ or -)
+//	        %-+  only the minus sign (- or nothing)
+//	%n, %t, %% - Newline, tab, and literal % characters
+//
+// Negative durations have a minus sign before their first number,
+// unless the format has a sign specifier.
+//
+//	FormatDuration("%:H:%M:%S", 38*time.Hour+4*time.Minute+12*time.Second) // 38:04:12
+func FormatDuration(fmt string, d time.Duration) string {
+	buf := buffer(fmt)
+	return string(AppendFormatDuration(buf, fmt, d))
+}
+
+// AppendFormatDuration is like FormatDuration, but appends the textual representation
+// to dst and returns the extended buffer.
+func AppendFormatDuration(dst []byte, fmt string, d time.Duration) []byte {
+	neg := d < 0
+	ns := uint64(d)
+	if neg {
+		ns = -ns
+	}
+	secs := ns / 1e9
+
+	signed := signedDuration(fmt)
+	var parser parser
+
+	parser.literal = func(b byte) error {
+		dst = append(dst, b)
+		return nil
+	}
+
+	parser.format = func(spec, flag byte) error {
+		if neg && !signed && durationNumber(spec) {
+			dst = append(dst, '-')
+			signed = true
+		}
+		switch spec {
+		case 'd':
+			dst = strconv.AppendUint(dst, secs/86400, 10)
+			return nil
+		case 'H':
+			if flag == ':' {
+				dst = appendSigned(dst, int(secs/3600), 2, false)
+			} else {
+				dst = appendInt2(dst, int(secs/3600%24), flag)
+			}
+			return nil
+		case 'M':
+			if flag == ':' {
+				dst = appendSigned(dst, int(secs/60), 2, false)
+			} else {
+				dst = appendInt2(dst, int(secs/60%60), flag)
+			}
+			return nil
+		case 'S':
+			dst = appendInt2(dst, int(secs%60), flag)
+			return nil
+		case 's':
+			dst = strconv.AppendUint(dst, secs, 10)
+			return nil
+		case 'Q':
+			dst = strconv.AppendUint(dst, ns/1e6, 10)
+			return nil
+		case 'L':
+			dst = appendSigned(dst, int(ns%1e9/1e6), 3, false)
+			return nil
+		case 'f':
+			dst = appendSigned(dst, int(ns%1e9/1e3), 6, false)
+			return nil
+		case 'N':
+			dst = appendSigned(dst, int(ns%1e9), 9, false)
+			return nil
+		case '+':
+			if neg {
+				dst = append(dst, '-')
+			} else if flag != '-' {
+				dst = append(dst, '+')
+			}
+			return nil
+		case '%', 't', 'n':
+			dst = append(dst, goLayout(spec, flag, false)...)
+			return nil
+		}
+
+		dst = append(dst, '%')
+		if flag != 0 {
+			dst = append(dst, flag)
+		}
+		dst = append(dst, spec)
+		return nil
+	}
+
+	parser.parse(fmt)
+	return dst
+}
+
+// signedDuration reports if a duration format has a sign specifier.
+func signedDuration(fmt string) bool {
+	var signed bool
+	var probe parser
+	probe.literal = func(byte) error { return nil }
+	probe.format = func(spec, flag byte) error {
+		signed = signed || spec == '+'
+		return nil
+	}
+	probe.parse(fmt)
+	return signed
+}
+
+// durationNumber reports if a duration specifier is a number.
+func durationNumber(spec byte) bool {
+	return strings.IndexByte("dHMSsQLfN", spec) >= 0
+}
+
+// ParseDuration converts a textual representation of a duration
+// to the duration it represents, according to the format specification.
+//
+// All FormatDuration specifiers are supported for parsing.
+// Components add up to the duration, and missing components are zero.
+// Unless the format has a sign specifier,
+// a minus sign before the first number makes the duration negative.
+func ParseDuration(fmt, value string) (time.Duration, error) {
+	signed := signedDuration(fmt)
+	var neg, overflow bool
+	var ns uint64
+	add := func(n, unit uint64) {
+		hi, lo := bits.Mul64(n, unit)
+		sum, carry := bits.Add64(ns, lo, 0)
+		overflow = overflow || hi != 0 || carry != 0
+		ns = sum
+	}
+
+	rest := value
+
+	var parser parser
+	parser.literal = func(b byte) error {
+		if len(rest) == 0 || rest[0] != b {
+			return &time.ParseError{
+				LayoutElem: string([]byte{b}),
+				ValueElem:  rest,
+			}
+		}
+		rest = rest[1:]
+		return nil
+	}
+
+	parser.format = func(spec, flag byte) error {
+		if !signed && durationNumber(spec) {
+			if strings.HasPrefix(rest, "-") {
+				neg, rest = true, rest[1:]
+			}
+			signed = true
+		}
+
+		var n int
+		var ok bool
+		var rng string
+		value := rest
+
+		switch spec {
+		default:
+			return formatError{}
+		case '%', 't', 'n':
+			for _, b := range []byte(goLayout(spec, flag, false)) {
+				if err := parser.literal(b); err != nil {
+					return err
+				}
+			}
+			return nil
+
+		case 'd', 's', 'Q':
+			var u uint64
+			var err error
+			for n = 0; isDigit(rest, n); n++ {
+			}
+			if u, err = strconv.ParseUint(rest[:n], 10, 64); err == nil {
+				ok, rest = true, rest[n:]
+				switch spec {
+				case 'd':
+					add(u, uint64(24*time.Hour))
+				case 's':
+					add(u, uint64(time.Second))
+				case 'Q':
+					add(u, uint64(time.Millisecond))
+				}
+			}
+		case 'H', 'M':
+			unit := uint64(time.Hour)
+			if spec == 'M' {
+				unit = uint64(time.Minute)
+			}
+			if flag == ':' {
+				n, rest, ok = getnum(rest, 1, 18)
+			} else {
+				n, rest, ok = getnum(rest, 1, 2)
+				if spec == 'H' && n > 23 {
+					rng = "hour"
+				}
+				if spec == 'M' && n > 59 {
+					rng = "minute"
+				}
+			}
+			add(uint64(n), unit)
+		case 'S':
+			n, rest, ok = getnum(rest, 1, 2)
+			if n > 59 {
+				rng = "second"
+			}
+			add(uint64(n), uint64(time.Second))
+		case 'L', 'f', 'N':
+			digits := 3
+			switch spec {
+			case 'f':
+				digits = 6
+			case 'N':
+				digits = 9
+			}
+			if _, rest, ok = getnum(rest, digits, digits); ok {
+				n, _ = getfrac("." + value[:digits])
+				add(uint64(n), 1)
+			}
+		case '+':
+			switch {
+			case strings.HasPrefix(rest, "-"):
+				neg, rest, ok = true, rest[1:], true
+			case strings.HasPrefix(rest, "+") && flag != '-':
+				rest, ok = rest[1:], true
+			default:
+				ok = flag == '-'
+			}
+		}
+
+		it := item{spec: spec, flag: flag}
+		if !ok {
+			return syntaxError(it, value)
+		}
+		if rng != "" {
+			return &time.ParseError{
+				LayoutElem: it.String(),
+				ValueElem:  value,
+				Message:    ": " + rng + " out of range",
+			}
+		}
+		return nil
+	}
+
+	err := parser.parse(fmt)
+	if err == nil && rest != "" {
+		err = &time.ParseError{Message: ": extra text: " + strconv.Quote(rest)}
+	}
+	if err == nil && (overflow || ns > 1<<63 || ns == 1<<63 && !neg) {
+		err = &time.ParseError{Message: ": duration out of range"}
+	}
+	if err != nil {
+		if err, ok := err.(*time.ParseError); ok {
+			err.Layout = fmt
+			err.Value = value
+		}
+		return 0, err
+	}
+
+	if neg {
+		return time.Duration(-ns), nil
+	}
+	return time.Duration(ns), nil
+}
@@ -0,0 +1,103 @@
+package strftime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestFormatDuration(t *testing.T) {
+	const d = 38*time.Hour + 4*time.Minute + 12*time.Second + 345678901
+
+	tests := []struct {
+		format   string
+		duration time.Duration
+		want     string
+	}{
+		{"%:H:%M:%S", d, "38:04:12"},
+		{"%d days %H:%M:%S.%L", d, "1 days 14:04:12.345"},
+		{"%dd %-Hh %-Mm %-Ss", d, "1d 14h 4m 12s"},
+		{"%:M:%S.%f", d, "2284:12.345678"},
+		{"%s.%N", d, "137052.345678901"},
+		{"%Q ms", d, "137052345 ms"},
+		{"%:H:%M", 5 * time.Minute, "00:05"},
+		{"%:H:%M", -5 * time.Minute, "-00:05"},
+		{"%+%:H:%M", 5 * time.Minute, "+00:05"},
+		{"%+%:H:%M", -5 * time.Minute, "-00:05"},
+		{"%-+%:H:%M", 5 * time.Minute, "00:05"},
+		{"T%-+%:H:%M", -5 * time.Minute, "T-00:05"},
+		{"took %H:%M", -90 * time.Minute, "took -01:30"},
+		{"%%%t%n%x", 0, "%\t\n%x"},
+		{"%s.%N", -1 << 63, "-9223372036.854775808"},
+	}
+
+	for _, test := range tests {
+		if got := strftime.FormatDuration(test.format, test.duration); got != test.want {
+			t.Errorf("FormatDuration(%q, %v) = %q, want %q", test.format, test.duration, got, test.want)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	const d = 38*time.Hour + 4*time.Minute + 12*time.Second + 345678901
+
+	tests := []struct {
+		format string
+		value  string
+		want   time.Duration
+	}{
+		{"%:H:%M:%S", "38:04:12", d.Truncate(time.Second)},
+		{"%d days %H:%M:%S.%N", "1 days 14:04:12.345678901", d},
+		{"%dd %-Hh %-Mm %-Ss", "1d 14h 4m 12s", d.Truncate(time.Second)},
+		{"%:M:%S.%f", "2284:12.345678", d.Truncate(time.Microsecond)},
+		{"%s.%N", "137052.345678901", d},
+		{"%Q ms", "137052345 ms", d.Truncate(time.Millisecond)},
+		{"%:H:%M", "-00:05", -5 * time.Minute},
+		{"took %H:%M", "took -01:30", -90 * time.Minute},
+		{"%+%:H:%M", "+00:05", 5 * time.Minute},
+		{"%-+%:H:%M", "-00:05", -5 * time.Minute},
+		{"%-+%:H:%M", "00:05", 5 * time.Minute},
+		{"%s.%N", "-9223372036.854775808", -1 << 63},
+		{"%s.%N", "9223372036.854775807", 1<<63 - 1},
+	}
+
+	for _, test := range tests {
+		if got, err := strftime.ParseDuration(test.format, test.value); err != nil {
+			t.Errorf("ParseDuration(%q, %q) = %v", test.format, test.value, err)
+		} else if got != test.want {
+			t.Errorf("ParseDuration(%q, %q) = %v, want %v", test.format, test.value, got, test.want)
+		}
+	}
+
+	errors := []struct {
+		format string
+		value  string
+	}{
+		{"%H:%M", "38:04"},
+		{"%:H:%M", "38:60"},
+		{"%:H:%M", "38:04:12"},
+		{"%:H:%M", "38-04"},
+		{"%+%:H:%M", "00:05"},
+		{"%s", "9223372037"},
+		{"%s.%N", "9223372036.854775808"},
+		{"%d %x", "1 x"},
+	}
+
+	for _, test := range errors {
+		if got, err := strftime.ParseDuration(test.format, test.value); err == nil {
+			t.Errorf("ParseDuration(%q, %q) = %v", test.format, test.value, got)
+		}
+	}
+
+	for _, d := range []time.Duration{0, 1, d, -d, 1<<63 - 1, -1 << 63} {
+		for _, format := range []string{"%d %H:%M:%S.%N", "%+%:H:%M:%S.%N", "%s.%N", "%-+%:M:%S.%N"} {
+			value := strftime.FormatDuration(format, d)
+			if got, err := strftime.ParseDuration(format, value); err != nil {
+				t.Errorf("ParseDuration(%q, %q) = %v", format, value, err)
+			} else if got != d {
+				t.Errorf("ParseDuration(%q, %q) = %v, want %v", format, value, got, d)
+			}
+		}
+	}
+}
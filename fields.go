@@ -0,0 +1,305 @@
+package strftime
+
+import "time"
+
+// Fields is implemented by time representations other than time.Time
+// (civil dates, times of day, timestamps) to format them.
+// Each method reports whether the representation has the field.
+//
+// Year, Month and Day are in the proleptic Gregorian calendar.
+// Fields that can be derived from others needn't be provided:
+// the month and day can be derived from the year and day of the year,
+// and the weekday and day of the year from the year, month and day.
+type Fields interface {
+	Year() (int, bool)
+	Month() (time.Month, bool)
+	Day() (int, bool)
+	Hour() (int, bool)
+	Minute() (int, bool)
+	Second() (int, bool)
+	Nanosecond() (int, bool)
+	Weekday() (time.Weekday, bool)
+	YearDay() (int, bool)
+	Zone() (name string, offset int, ok bool)
+}
+
+// NoFields provides none of the Fields.
+// Embed it to implement only some of them.
+type NoFields struct{}
+
+func (NoFields) Year() (int, bool)                        { return 0, false }
+func (NoFields) Month() (time.Month, bool)                { return 0, false }
+func (NoFields) Day() (int, bool)                         { return 0, false }
+func (NoFields) Hour() (int, bool)                        { return 0, false }
+func (NoFields) Minute() (int, bool)                      { return 0, false }
+func (NoFields) Second() (int, bool)                      { return 0, false }
+func (NoFields) Nanosecond() (int, bool)                  { return 0, false }
+func (NoFields) Weekday() (time.Weekday, bool)            { return 0, false }
+func (NoFields) YearDay() (int, bool)                     { return 0, false }
+func (NoFields) Zone() (name string, offset int, ok bool) { return "", 0, false }
+
+// TimeFields returns the Fields of a time value, which has all of them.
+func TimeFields(t time.Time) Fields {
+	return timeFields{t}
+}
+
+type timeFields struct{ t time.Time }
+
+func (f timeFields) Year() (int, bool)             { return f.t.Year(), true }
+func (f timeFields) Month() (time.Month, bool)     { return f.t.Month(), true }
+func (f timeFields) Day() (int, bool)              { return f.t.Day(), true }
+func (f timeFields) Hour() (int, bool)             { return f.t.Hour(), true }
+func (f timeFields) Minute() (int, bool)           { return f.t.Minute(), true }
+func (f timeFields) Second() (int, bool)           { return f.t.Second(), true }
+func (f timeFields) Nanosecond() (int, bool)       { return f.t.Nanosecond(), true }
+func (f timeFields) Weekday() (time.Weekday, bool) { return f.t.Weekday(), true }
+func (f timeFields) YearDay() (int, bool)          { return f.t.YearDay(), true }
+
+func (f timeFields) Zone() (name string, offset int, ok bool) {
+	name, offset = f.t.Zone()
+	return name, offset, true
+}
+
+// FormatFields is like Format, but formats the fields of a time representation.
+// It returns an error if the format uses a field that f doesn't provide.
+func FormatFields(fmt string, f Fields) (string, error) {
+	buf := buffer(fmt)
+	buf, err := AppendFormatFields(buf, fmt, f)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// AppendFormatFields is like FormatFields, but appends the textual representation
+// to dst and returns the extended buffer.
+// On error, dst is returned unchanged.
+func AppendFormatFields(dst []byte, fmt string, f Fields) ([]byte, error) {
+	return defaultLocale.AppendFormatFields(dst, fmt, f)
+}
+
+// FormatFields is like the package level FormatFields, but uses the locale.
+func (l *Locale) FormatFields(fmt string, f Fields) (string, error) {
+	buf := buffer(fmt)
+	buf, err := l.AppendFormatFields(buf, fmt, f)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// AppendFormatFields is like the package level AppendFormatFields, but uses the locale.
+func (l *Locale) AppendFormatFields(dst []byte, fmt string, f Fields) ([]byte, error) {
+	t, has := fieldsTime(f)
+	buf, err := appendFormat(dst, fmt, t, l, has)
+	if err != nil {
+		return dst, err
+	}
+	return buf, nil
+}
+
+// provided is the set of fields a time value provides.
+type provided uint16
+
+const (
+	hasYear provided = 1 << iota
+	hasMonth
+	hasDay
+	hasYearDay
+	hasWeekday
+	hasHour
+	hasMinute
+	hasSecond
+	hasNanosecond
+	hasZone
+
+	hasDate  = hasYear | hasMonth | hasDay | hasYearDay | hasWeekday
+	hasClock = hasHour | hasMinute | hasSecond | hasNanosecond
+	hasAll   = hasDate | hasClock | hasZone
+)
+
+var fieldNames = [...]string{
+	"year", "month", "day", "day of the year", "weekday",
+	"hour", "minute", "second", "nanosecond", "zone",
+}
+
+// fieldsTime converts fields to a time value,
+// and returns the fields it provides, directly or derived from others.
+func fieldsTime(f Fields) (time.Time, provided) {
+	if f, ok := f.(timeFields); ok {
+		return f.t, hasAll
+	}
+
+	var has provided
+	year, okYear := f.Year()
+	month, okMonth := f.Month()
+	day, okDay := f.Day()
+	yday, okYearDay := f.YearDay()
+	wday, okWeekday := f.Weekday()
+	hour, okHour := f.Hour()
+	min, okMinute := f.Minute()
+	sec, okSecond := f.Second()
+	nsec, okNanosecond := f.Nanosecond()
+	name, offset, okZone := f.Zone()
+
+	for i, ok := range []bool{
+		okYear, okMonth, okDay, okYearDay, okWeekday,
+		okHour, okMinute, okSecond, okNanosecond, okZone,
+	} {
+		if ok {
+			has |= 1 << i
+		}
+	}
+
+	loc := time.UTC
+	if okZone {
+		loc = time.FixedZone(name, offset)
+	}
+
+	// Missing date fields default to a leap year.
+	switch {
+	case okYear && okMonth && okDay:
+		has |= hasDate
+	case okYear && okYearDay:
+		month, day = time.January, yday
+		has |= hasDate
+	default:
+		if !okYear {
+			year = 2000
+		}
+		if !okMonth {
+			month = time.January
+		}
+		if !okDay {
+			day = 1
+		}
+		if okYearDay && !okMonth && !okDay {
+			day = yday
+		}
+		if okWeekday && !okYearDay {
+			year, month, day = weekdayDate(year, month, day, wday, has)
+		}
+	}
+
+	// Drop fields the time value doesn't match (e.g. February 30).
+	t := time.Date(year, month, day, hour, min, sec, nsec, loc)
+	if okYear && t.Year() != year {
+		has &^= hasDate
+	}
+	if okMonth && t.Month() != month || okDay && t.Day() != day {
+		has &^= hasDate &^ hasYear
+	}
+	if okYearDay && t.YearDay() != yday {
+		has &^= hasYearDay
+	}
+	if okWeekday && t.Weekday() != wday {
+		has &^= hasWeekday
+	}
+	return t, has
+}
+
+// weekdayDate returns a date with the weekday,
+// changing the fields of the date that aren't provided.
+func weekdayDate(year int, month time.Month, day int, wday time.Weekday, has provided) (int, time.Month, int) {
+	if has&hasDay == 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		return year, month, 1 + int(wday-first+7)%7
+	}
+
+	// The Gregorian calendar repeats its weekdays every 28 years
+	// from 1901 to 2099.
+	years, months := 1, 1
+	if has&hasYear == 0 {
+		years = 28
+	}
+	if has&hasMonth == 0 {
+		months = 12
+	}
+	for y := year; y < year+years; y++ {
+		for m := month; m < month+time.Month(months); m++ {
+			t := time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+			if t.Day() == day && t.Weekday() == wday {
+				return y, m, day
+			}
+		}
+	}
+	return year, month, day
+}
+
+// needs returns the fields a directive needs.
+func (l *Locale) needs(spec, flag, mod byte) provided {
+	// Other calendars need the Gregorian date.
+	date := func(p provided) provided {
+		if l.Calendar != nil {
+			return hasDate
+		}
+		return p
+	}
+
+	switch spec {
+	case 'Y', 'C', 'y':
+		if mod == 'E' && len(l.Eras) > 0 {
+			return hasDate
+		}
+		return date(hasYear)
+	case 'm', 'B', 'b', 'h':
+		return date(hasMonth)
+	case 'd', 'e':
+		return date(hasDay)
+	case 'j':
+		return date(hasYearDay)
+	case 'a', 'A', 'u':
+		return hasWeekday
+	case 'w':
+		if flag == ':' {
+			return date(hasDay)
+		}
+		return hasWeekday
+	case 'W':
+		if flag == ':' {
+			return date(hasDay | hasWeekday)
+		}
+		return hasDate
+	case 'U', 'V', 'G', 'g':
+		return hasDate
+	case 'J', 'K', 'i':
+		if flag == ':' {
+			return hasDate | hasClock
+		}
+		return hasDate
+	case 's':
+		return hasDate | hasHour | hasMinute | hasSecond | hasZone
+	case 'Q':
+		return hasDate | hasClock | hasZone
+	case 'p', 'P', 'H', 'k', 'I', 'l':
+		return hasHour
+	case 'M':
+		return hasMinute
+	case 'S':
+		return hasSecond
+	case 'L', 'f', 'N':
+		return hasNanosecond
+	case 'z', 'Z':
+		return hasZone
+	}
+	return 0
+}
+
+// missingField returns the name of the first missing field.
+func missingField(missing provided) string {
+	for i, name := range fieldNames {
+		if missing&(1<<i) != 0 {
+			return name
+		}
+	}
+	return ""
+}
+
+type fieldError struct {
+	directive string
+	field     string
+}
+
+func (e fieldError) Error() string {
+	return "strftime: directive " + e.directive + " needs the " + e.field + ", which the value doesn't provide"
+}
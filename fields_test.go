@@ -0,0 +1,112 @@
+package strftime_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+type civilDate struct {
+	strftime.NoFields
+	year  int
+	month time.Month
+	day   int
+}
+
+func (d civilDate) Year() (int, bool)         { return d.year, true }
+func (d civilDate) Month() (time.Month, bool) { return d.month, true }
+func (d civilDate) Day() (int, bool)          { return d.day, true }
+
+type timeOfDay struct {
+	strftime.NoFields
+	hour, min, sec int
+}
+
+func (t timeOfDay) Hour() (int, bool)   { return t.hour, true }
+func (t timeOfDay) Minute() (int, bool) { return t.min, true }
+func (t timeOfDay) Second() (int, bool) { return t.sec, true }
+
+type weekday struct {
+	strftime.NoFields
+	day time.Weekday
+}
+
+func (w weekday) Weekday() (time.Weekday, bool) { return w.day, true }
+
+type ordinalDate struct {
+	strftime.NoFields
+	year, yday int
+}
+
+func (d ordinalDate) Year() (int, bool)    { return d.year, true }
+func (d ordinalDate) YearDay() (int, bool) { return d.yday, true }
+
+func TestFormatFields(t *testing.T) {
+	tests := []struct {
+		format string
+		fields strftime.Fields
+		want   string
+	}{
+		{"%A, %B %-d%o %Y", civilDate{year: 2024, month: 3, day: 3}, "Sunday, March 3rd 2024"},
+		{"%F %j %G-W%V-%u", civilDate{year: 2024, month: 12, day: 30}, "2024-12-30 365 2025-W01-1"},
+		{"%D %J", civilDate{year: 2000, month: 1, day: 1}, "01/01/00 2451545"},
+		{"%T %r", timeOfDay{hour: 14, min: 5, sec: 9}, "14:05:09 02:05:09 PM"},
+		{"%a %A", weekday{day: time.Wednesday}, "Wed Wednesday"},
+		{"%F %a", ordinalDate{year: 2024, yday: 60}, "2024-02-29 Thu"},
+		{"%c %Z", strftime.TimeFields(time.Date(2009, 2, 3, 4, 5, 6, 0, time.UTC)), "Tue Feb  3 04:05:06 2009 UTC"},
+	}
+
+	for _, test := range tests {
+		got, err := strftime.FormatFields(test.format, test.fields)
+		if err != nil {
+			t.Errorf("FormatFields(%q, %v) error = %v", test.format, test.fields, err)
+		} else if got != test.want {
+			t.Errorf("FormatFields(%q, %v) = %q, want %q", test.format, test.fields, got, test.want)
+		}
+	}
+}
+
+func TestFormatFields_errors(t *testing.T) {
+	tests := []struct {
+		format string
+		fields strftime.Fields
+		want   string
+	}{
+		{"%F %T", civilDate{year: 2024, month: 3, day: 3}, "%H needs the hour"},
+		{"%F %z", civilDate{year: 2024, month: 3, day: 3}, "%z needs the zone"},
+		{"%s", civilDate{year: 2024, month: 3, day: 3}, "%s needs the hour"},
+		{"%F", civilDate{year: 2024, month: 2, day: 30}, "%m needs the month"},
+		{"%T.%L", timeOfDay{hour: 14, min: 5, sec: 9}, "%L needs the nanosecond"},
+		{"%R %-d%o", timeOfDay{hour: 14, min: 5, sec: 9}, "%-d needs the day"},
+		{"%p%o", timeOfDay{hour: 14, min: 5, sec: 9}, "%o needs the day"},
+		{"%x", timeOfDay{hour: 14, min: 5, sec: 9}, "%m needs the month"},
+		{"%A %V", weekday{day: time.Wednesday}, "%V needs the year"},
+		{"%A", strftime.NoFields{}, "%A needs the weekday"},
+	}
+
+	for _, test := range tests {
+		_, err := strftime.FormatFields(test.format, test.fields)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("FormatFields(%q, %v) error = %v, want %q", test.format, test.fields, err, test.want)
+		}
+	}
+
+	buf := []byte("keep")
+	if got, err := strftime.AppendFormatFields(buf, "%Y", strftime.NoFields{}); err == nil || string(got) != "keep" {
+		t.Errorf("AppendFormatFields() = (%q, %v)", got, err)
+	}
+}
+
+func TestLocale_FormatFields(t *testing.T) {
+	d := civilDate{year: 2019, month: 5, day: 1}
+	if got, err := strftime.Japanese.FormatFields("%EY%-m月%-d日(%a)", d); err != nil || got != "令和元年5月1日(水)" {
+		t.Errorf("FormatFields() = (%q, %v)", got, err)
+	}
+
+	persian := &strftime.Locale{Calendar: strftime.SolarHijri}
+	if _, err := persian.FormatFields("%B", timeOfDay{}); err == nil {
+		t.Error("want error")
+	}
+}
@@ -16,7 +16,8 @@ func FuzzFormat(f *testing.F) {
 
 	f.Fuzz(func(t *testing.T, format string) {
 		str := strftime.Format(format, reference)
-		if str == "" && format != "" {
+		// Only an empty alternative of a section formats as nothing.
+		if str == "" && format != "" && !strings.Contains(format, "%]") {
 			t.Errorf("Format(%q) = %q", format, str)
 		}
 		if str != format && !strings.Contains(format, "%") {
@@ -69,3 +70,16 @@ func FuzzUTS35(f *testing.F) {
 		}
 	})
 }
+
+func FuzzParseDuration(f *testing.F) {
+	f.Add("%:H:%M:%S", "38:04:12")
+	f.Add("%+%d %H:%M:%S.%N", "-1 14:04:12.345678901")
+	f.Add("%s.%N", "9223372036.854775807")
+
+	f.Fuzz(func(t *testing.T, format, value string) {
+		parsed, err := strftime.ParseDuration(format, value)
+		if err != nil && parsed != 0 {
+			t.Errorf("ParseDuration(%q, %q) = (%v, %v)", format, value, parsed, err)
+		}
+	})
+}
@@ -0,0 +1,396 @@
+package strftime
+
+import (
+	"sort"
+	"strings"
+)
+
+// A Candidate is a format inferred from sample values.
+// Confidence is between 0 and 1,
+// and sums to 1 over the candidates for the same samples.
+type Candidate struct {
+	Format     string
+	Confidence float64
+}
+
+// InferFormat infers the format of sample values
+// (e.g. a column of dates in a CSV file).
+// It returns the candidate formats that parse all the samples,
+// from the most to the least likely.
+//
+// All samples are used to resolve ambiguities
+// (e.g. 03/04/2024 is either %d/%m/%Y or %m/%d/%Y, but 13/04/2024 isn't),
+// and formats that format the samples back as they are rank higher.
+// Recognized formats include ISO 8601, RFC 2822,
+// seconds or milliseconds since the epoch, and common log formats.
+func InferFormat(samples ...string) []Candidate {
+	return defaultLocale.InferFormat(samples...)
+}
+
+// InferFormat is like the package level InferFormat, but uses the locale.
+func (l *Locale) InferFormat(samples ...string) []Candidate {
+	// The likelihood of each format, before looking at the samples.
+	priors := map[string]float64{}
+	var formats []string
+	for _, s := range samples {
+		g := inference{locale: l, tokens: tokenize(s)}
+		for _, t := range g.tokens {
+			if t.kind == 'a' && isName(t.text, l.dayPeriods()) {
+				g.hour12 = true
+			}
+		}
+		g.infer(func(format string, prior float64) {
+			if p, ok := priors[format]; !ok {
+				formats = append(formats, format)
+				priors[format] = prior
+			} else if p < prior {
+				priors[format] = prior
+			}
+		})
+	}
+
+	var total float64
+	var candidates []Candidate
+	p := Parser{Locale: l, Validate: true}
+next:
+	for _, format := range formats {
+		exact := 0
+		for _, s := range samples {
+			t, err := p.Parse(format, s)
+			if err != nil {
+				continue next
+			}
+			if l.Format(format, t) == s {
+				exact++
+			}
+		}
+		weight := priors[format] * float64(1+exact) / float64(1+len(samples))
+		candidates = append(candidates, Candidate{format, weight})
+		total += weight
+	}
+
+	for i := range candidates {
+		candidates[i].Confidence /= total
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+	return candidates
+}
+
+// token is a run of digits ('0') or letters ('a'), or any other byte.
+type token struct {
+	text string
+	kind byte
+}
+
+func tokenize(s string) []token {
+	var tokens []token
+	for len(s) > 0 {
+		var kind byte
+		n := 1
+		switch {
+		case isDigit(s, 0):
+			kind = '0'
+			for isDigit(s, n) {
+				n++
+			}
+		case isLetter(s, 0):
+			kind = 'a'
+			for isLetter(s, n) {
+				n++
+			}
+		}
+		tokens = append(tokens, token{s[:n], kind})
+		s = s[n:]
+	}
+	return tokens
+}
+
+func isLetter(s string, i int) bool {
+	return i < len(s) && ('a' <= s[i]|0x20 && s[i]|0x20 <= 'z' || s[i] >= 0x80)
+}
+
+// isName reports if s is one of the names, ignoring case.
+func isName(s string, names []string) bool {
+	for _, name := range names {
+		if strings.EqualFold(s, name) || strings.EqualFold(s+".", name) {
+			return true
+		}
+	}
+	return false
+}
+
+// inference is the state of inferring formats from the tokens of a sample.
+type inference struct {
+	locale *Locale
+	tokens []token
+	hour12 bool // the sample has AM/PM
+}
+
+// option is a way of formatting some of the tokens of a sample.
+type option struct {
+	format string
+	tokens int
+	prior  float64
+}
+
+// beamWidth is the number of partial formats kept at each token:
+// the most likely ones, so that the search grows linearly with the sample.
+const beamWidth = 16
+
+// partial is a format for the tokens before some token.
+type partial struct {
+	format string
+	prior  float64
+}
+
+// infer calls emit with the most likely formats that match the tokens.
+func (g *inference) infer(emit func(string, float64)) {
+	beams := make([][]partial, len(g.tokens)+1)
+	beams[0] = []partial{{"", 1}}
+	for i := range g.tokens {
+		if len(beams[i]) == 0 {
+			continue
+		}
+		options := g.options(i)
+		for _, p := range beams[i] {
+			for _, o := range options {
+				j := i + o.tokens
+				beams[j] = keep(beams[j], partial{p.format + o.format, p.prior * o.prior})
+			}
+		}
+	}
+	for _, p := range beams[len(g.tokens)] {
+		emit(p.format, p.prior)
+	}
+}
+
+// keep adds p to a beam, sorted from the most to the least likely,
+// and drops the least likely if there are more than beamWidth.
+func keep(beam []partial, p partial) []partial {
+	i := sort.Search(len(beam), func(i int) bool { return beam[i].prior < p.prior })
+	if i >= beamWidth {
+		return beam
+	}
+	if len(beam) < beamWidth {
+		beam = append(beam, partial{})
+	}
+	copy(beam[i+1:], beam[i:])
+	beam[i] = p
+	return beam
+}
+
+// options returns the ways of formatting the tokens from i,
+// preferring those that match more tokens.
+func (g *inference) options(i int) []option {
+	if o := g.dateOptions(i); o != nil {
+		return o
+	}
+	if o := g.timeOptions(i); o != nil {
+		return o
+	}
+
+	t := g.tokens[i]
+	switch t.kind {
+	case '0':
+		return g.numberOptions(i)
+	case 'a':
+		return g.nameOptions(i)
+	}
+
+	switch t.text {
+	case "+", "-":
+		// Zone offsets: +0100, +01:00.
+		if g.digits(i+1, 4, 4) {
+			return []option{{"%z", 2, 1}}
+		}
+		if g.digits(i+1, 2, 2) && g.text(i+2, ":") && g.digits(i+3, 2, 2) {
+			return []option{{"%:z", 4, 1}}
+		}
+	case " ":
+		// Blank-padded days: Mar  3.
+		if g.text(i+1, " ") && g.digits(i+2, 1, 1) {
+			return []option{{" %e", 3, 1}}
+		}
+	case "%":
+		return []option{{"%%", 1, 1}}
+	}
+	return []option{{t.text, 1, 1}}
+}
+
+// dateOptions returns the numeric dates that start at i:
+// 2024-03-03, 03/03/2024, 03.03.24, 2024-063, 2024-03.
+func (g *inference) dateOptions(i int) []option {
+	if !g.digits(i, 1, 4) || !g.digits(i+2, 1, 3) {
+		return nil
+	}
+	sep := g.tokens[i+1].text
+	if sep != "-" && sep != "/" && sep != "." {
+		return nil
+	}
+
+	a, b := g.tokens[i].text, g.tokens[i+2].text
+	if !g.text(i+3, sep) || !g.digits(i+4, 1, 4) {
+		switch {
+		case sep != "-" || len(a) != 4:
+			return nil
+		case len(b) == 3:
+			return []option{{"%Y-%j", 3, 1}}
+		case len(b) == 2:
+			return []option{{"%Y-%m", 3, 1}}
+		}
+		return nil
+	}
+	c := g.tokens[i+4].text
+
+	date := func(prior float64, specs ...string) option {
+		fmt := specs[0] + sep + specs[1] + sep + specs[2]
+		if fmt == "%Y-%m-%d" {
+			fmt = "%F"
+		}
+		return option{fmt, 5, prior}
+	}
+	switch {
+	case len(a) == 4 && len(b) <= 2 && len(c) <= 2:
+		return []option{
+			date(1, "%Y", pad("%m", b), pad("%d", c)),
+			date(0.1, "%Y", pad("%d", b), pad("%m", c)),
+		}
+	case len(a) <= 2 && len(b) <= 2 && len(c) == 4:
+		return []option{
+			date(1, pad("%d", a), pad("%m", b), "%Y"),
+			date(1, pad("%m", a), pad("%d", b), "%Y"),
+		}
+	case len(a) <= 2 && len(b) <= 2 && len(c) == 2:
+		return []option{
+			date(1, pad("%d", a), pad("%m", b), "%y"),
+			date(1, pad("%m", a), pad("%d", b), "%y"),
+			date(0.5, "%y", pad("%m", b), pad("%d", c)),
+		}
+	}
+	return nil
+}
+
+// timeOptions returns the times of day that start at i:
+// 10:00, 10:00:01, 10:00:01.500.
+func (g *inference) timeOptions(i int) []option {
+	if !g.digits(i, 1, 2) || !g.text(i+1, ":") || !g.digits(i+2, 2, 2) {
+		return nil
+	}
+
+	hour := pad("%H", g.tokens[i].text)
+	if g.hour12 {
+		hour = pad("%I", g.tokens[i].text)
+	}
+	if !g.text(i+3, ":") || !g.digits(i+4, 2, 2) {
+		if hour == "%H" {
+			return []option{{"%R", 3, 1}}
+		}
+		return []option{{hour + ":%M", 3, 1}}
+	}
+
+	fmt := hour + ":%M:%S"
+	if fmt == "%H:%M:%S" {
+		fmt = "%T"
+	}
+	n := 5
+	if (g.text(i+5, ".") || g.text(i+5, ",")) && g.digits(i+6, 1, 9) {
+		// Other fractions are parsed with the seconds.
+		sep := g.tokens[i+5].text
+		switch len(g.tokens[i+6].text) {
+		case 3:
+			fmt += sep + "%L"
+		case 6:
+			fmt += sep + "%f"
+		case 9:
+			fmt += sep + "%N"
+		}
+		n = 7
+	}
+	return []option{{fmt, n, 1}}
+}
+
+// numberOptions returns the numbers that start at i.
+func (g *inference) numberOptions(i int) []option {
+	t := g.tokens[i].text
+	switch len(t) {
+	case 1, 2:
+		j := i + 1
+		for g.text(j, " ") {
+			j++
+		}
+		if g.hour12 && j < len(g.tokens) && isName(g.tokens[j].text, g.locale.dayPeriods()) {
+			return []option{{pad("%I", t), 1, 1}}
+		}
+		return []option{{pad("%d", t), 1, 1}}
+	case 4:
+		return []option{{"%Y", 1, 1}, {"%H%M", 1, 0.1}}
+	case 6:
+		return []option{{"%H%M%S", 1, 0.5}, {"%y%m%d", 1, 0.5}}
+	case 8:
+		return []option{{"%Y%m%d", 1, 1}}
+	case 10:
+		return []option{{"%s", 1, 1}}
+	case 12:
+		return []option{{"%Y%m%d%H%M", 1, 1}}
+	case 13:
+		return []option{{"%Q", 1, 1}}
+	case 14:
+		return []option{{"%Y%m%d%H%M%S", 1, 1}}
+	}
+	return nil
+}
+
+// nameOptions returns the names that start at i.
+func (g *inference) nameOptions(i int) []option {
+	l := g.locale
+	t := g.tokens[i].text
+	switch {
+	case isName(t, l.monthNames(false)):
+		return []option{{"%B", 1, 1}}
+	case isName(t, l.monthNames(true)):
+		return []option{{"%b", 1, 1}}
+	case isName(t, l.days(false)):
+		return []option{{"%A", 1, 1}}
+	case isName(t, l.days(true)):
+		return []option{{"%a", 1, 1}}
+	case isName(t, l.dayPeriods()):
+		return []option{{"%p", 1, 1}}
+	case i > 0 && g.tokens[i-1].kind == '0' && g.ordinal(g.tokens[i-1].text, t):
+		return []option{{"%o", 1, 1}}
+	case t == "Z":
+		return []option{{"Z", 1, 1}, {"%z", 1, 1}}
+	case len(t) >= 2 && len(t) <= 5 && strings.ToUpper(t) == t:
+		return []option{{"%Z", 1, 1}, {t, 1, 0.5}}
+	}
+	return []option{{t, 1, 1}}
+}
+
+// ordinal reports if suffix is the ordinal suffix of number.
+func (g *inference) ordinal(number, suffix string) bool {
+	n, _ := atoi(number)
+	return strings.EqualFold(suffix, g.locale.ordinal(n))
+}
+
+// digits reports if token i has between min and max digits.
+func (g *inference) digits(i, min, max int) bool {
+	if i >= len(g.tokens) || g.tokens[i].kind != '0' {
+		return false
+	}
+	n := len(g.tokens[i].text)
+	return min <= n && n <= max
+}
+
+// text reports if token i is s.
+func (g *inference) text(i int, s string) bool {
+	return i < len(g.tokens) && g.tokens[i].text == s
+}
+
+// pad returns the unpadded directive for numbers with a single digit.
+func pad(spec, number string) string {
+	if len(number) == 1 {
+		return "%-" + spec[1:]
+	}
+	return spec
+}
@@ -0,0 +1,90 @@
+package strftime_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestInferFormat(t *testing.T) {
+	tests := []struct {
+		samples []string
+		want    string
+	}{
+		{[]string{"2024-03-03"}, "%F"},
+		{[]string{"2024-03-03T10:00:01Z", "2024-03-04T11:30:00Z"}, "%FT%TZ"},
+		{[]string{"2024-03-03T10:00:01+01:00"}, "%FT%T%:z"},
+		{[]string{"2024-03-03 10:00:01.500"}, "%F %T.%L"},
+		{[]string{"Sun, 03 Mar 2024 10:00:01 +0100"}, "%a, %d %b %Y %T %z"},
+		{[]string{"03/Mar/2024:10:00:01 +0100"}, "%d/%b/%Y:%T %z"},
+		{[]string{"Mar  3 10:00:01", "Mar 13 10:00:01"}, "%b %e %T"},
+		{[]string{"March 3rd, 2024"}, "%B %-d%o, %Y"},
+		{[]string{"03/04/2024", "13/04/2024"}, "%d/%m/%Y"},
+		{[]string{"03/04/2024", "04/13/2024"}, "%m/%d/%Y"},
+		{[]string{"3/4/2024", "3/13/2024"}, "%-m/%-d/%Y"},
+		{[]string{"Sun 03/03/24", "Mon 04/03/24"}, "%a %d/%m/%y"},
+		{[]string{"2024-063"}, "%Y-%j"},
+		{[]string{"20240303"}, "%Y%m%d"},
+		{[]string{"1709460001"}, "%s"},
+		{[]string{"1709460001500"}, "%Q"},
+		{[]string{"10:05 PM"}, "%I:%M %p"},
+		{[]string{"03.03.2024 10:00"}, "%d.%m.%Y %R"},
+		{[]string{"2024-03-03 10:00:01 UTC"}, "%F %T %Z"},
+	}
+
+	for _, test := range tests {
+		got := strftime.InferFormat(test.samples...)
+		if len(got) == 0 {
+			t.Errorf("InferFormat(%q) = nil, want %q", test.samples, test.want)
+			continue
+		}
+		if got[0].Format != test.want {
+			t.Errorf("InferFormat(%q) = %v, want %q", test.samples, got, test.want)
+		}
+
+		var sum float64
+		for _, c := range got {
+			sum += c.Confidence
+			for _, s := range test.samples {
+				if _, err := strftime.Parse(c.Format, s); err != nil {
+					t.Errorf("InferFormat(%q) = %q, which fails: %v", test.samples, c.Format, err)
+				}
+			}
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("InferFormat(%q) = %v, want confidences adding up to 1", test.samples, got)
+		}
+	}
+}
+
+func TestInferFormat_ambiguous(t *testing.T) {
+	got := strftime.InferFormat("03/04/2024", "05/06/2024")
+	if len(got) != 2 || got[0].Confidence != got[1].Confidence {
+		t.Fatalf("InferFormat() = %v", got)
+	}
+	if got[0].Format != "%d/%m/%Y" || got[1].Format != "%m/%d/%Y" {
+		t.Errorf("InferFormat() = %v", got)
+	}
+
+	if got := strftime.InferFormat("03/04/2024", "not a date"); got != nil {
+		t.Errorf("InferFormat() = %v, want nil", got)
+	}
+	if got := strftime.InferFormat(); got != nil {
+		t.Errorf("InferFormat() = %v, want nil", got)
+	}
+}
+
+func TestInferFormat_long(t *testing.T) {
+	// Each number is either a year or a time,
+	// so there are 2^30 ways to format the sample.
+	sample := strings.TrimSpace(strings.Repeat("1234 ", 30))
+	got := strftime.InferFormat(sample)
+	if len(got) == 0 || len(got) > 16 {
+		t.Fatalf("InferFormat() = %d candidates", len(got))
+	}
+	if want := strings.TrimSpace(strings.Repeat("%Y ", 30)); got[0].Format != want {
+		t.Errorf("InferFormat() = %q, want %q", got[0].Format, want)
+	}
+}
@@ -0,0 +1,234 @@
+package strftime
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FormatInterval returns a textual representation of the time interval
+// from start to end, formatted according to the strftime format specification.
+//
+// Fields shared by both ends are formatted only once,
+// following the greatest differing field approach of CLDR interval formats:
+// directives for fields greater than the greatest field that differs
+// between start and end are formatted once,
+// and the part of the format between the others is repeated for each end.
+// If the ends only differ in fields the format doesn't have,
+// the interval is formatted as a single time.
+// Both ends should be in the same location.
+//
+//	FormatInterval("%b %-d, %Y", mar3, mar5)  // Mar 3–5, 2024
+//	FormatInterval("%b %-d, %Y", mar3, apr5)  // Mar 3 – Apr 5, 2024
+//	FormatInterval("%H:%M %Z", t1000, t1130)  // 10:00–11:30 CET
+func FormatInterval(fmt string, start, end time.Time) string {
+	buf := buffer(fmt)
+	return string(AppendFormatInterval(buf, fmt, start, end))
+}
+
+// AppendFormatInterval is like FormatInterval, but appends the textual representation
+// to dst and returns the extended buffer.
+func AppendFormatInterval(dst []byte, fmt string, start, end time.Time) []byte {
+	return defaultLocale.AppendFormatInterval(dst, fmt, start, end)
+}
+
+// FormatInterval is like the package level FormatInterval, but uses the locale.
+func (l *Locale) FormatInterval(fmt string, start, end time.Time) string {
+	buf := buffer(fmt)
+	return string(l.AppendFormatInterval(buf, fmt, start, end))
+}
+
+// AppendFormatInterval is like the package level AppendFormatInterval, but uses the locale.
+func (l *Locale) AppendFormatInterval(dst []byte, fmt string, start, end time.Time) []byte {
+	field := l.intervalField(start, end)
+	if field < 0 {
+		return l.AppendFormat(dst, fmt, start)
+	}
+
+	if fmt, ok := l.IntervalFormats[fmt][intervalFields[field]]; ok {
+		tokens := intervalTokens(fmt)
+		i := repeatedField(tokens)
+		dst = l.AppendFormat(dst, joinTokens(tokens[:i]), start)
+		return l.AppendFormat(dst, joinTokens(tokens[i:]), end)
+	}
+
+	// Find the directives that differ between the ends.
+	// As in CLDR, dates with times are repeated in full
+	// if they differ in the date.
+	tokens := intervalTokens(fmt)
+	first, last := -1, -1
+	var shared, dated, timed bool
+	for i, tok := range tokens {
+		if tok.lit {
+			continue
+		}
+		if tok.field >= 0 {
+			dated = dated || tok.field <= dayField
+			timed = timed || tok.field > dayField
+		}
+		if tok.field >= field || l.Format(tok.text, start) != l.Format(tok.text, end) {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		} else {
+			shared = true
+		}
+	}
+	if first < 0 {
+		return l.AppendFormat(dst, fmt, start)
+	}
+	if !shared || dated && timed && field <= dayField {
+		first, last = 0, len(tokens)-1
+	}
+
+	inner := joinTokens(tokens[first : last+1])
+	dst = l.AppendFormat(dst, joinTokens(tokens[:first]), start)
+	from := len(dst)
+	dst = l.AppendFormat(dst, inner, start)
+	to := len(dst)
+	dst = l.AppendFormat(dst, inner, end)
+
+	sep := l.intervalSeparators()[0]
+	if bytes.IndexFunc(dst[from:], unicode.IsSpace) >= 0 {
+		sep = l.intervalSeparators()[1]
+	}
+	dst = append(dst[:to], append([]byte(sep), dst[to:]...)...)
+	return l.AppendFormat(dst, joinTokens(tokens[last+1:]), end)
+}
+
+// The fields of intervals, from the greatest.
+const (
+	intervalFields = "EYmdpHMSN"
+	dayField       = 3
+)
+
+// intervalField returns the greatest field that differs between start and end,
+// as an index into intervalFields, or -1 if none does.
+func (l *Locale) intervalField(start, end time.Time) int {
+	y1, m1, d1 := l.date(start)
+	y2, m2, d2 := l.date(end)
+	e1, _ := era(y1)
+	e2, _ := era(y2)
+	a1, _, ok1 := l.altEra(start)
+	a2, _, ok2 := l.altEra(end)
+
+	switch {
+	case e1 != e2 || a1 != a2 || ok1 != ok2:
+		return 0
+	case y1 != y2:
+		return 1
+	case m1 != m2:
+		return 2
+	case d1 != d2:
+		return 3
+	case start.Hour()/12 != end.Hour()/12:
+		return 4
+	case start.Hour() != end.Hour():
+		return 5
+	case start.Minute() != end.Minute():
+		return 6
+	case start.Second() != end.Second():
+		return 7
+	case start.Nanosecond() != end.Nanosecond():
+		return 8
+	}
+	return -1
+}
+
+// directiveField returns the field of a directive,
+// as an index into intervalFields, or -1 if it has none.
+func directiveField(spec, flag, mod byte) int {
+	switch spec {
+	case 'C':
+		if mod == 'E' || flag == ':' {
+			return 0
+		}
+		return 1
+	case 'Y', 'y', 'G', 'g':
+		return 1
+	case 'm', 'B', 'b', 'h':
+		return 2
+	case 'd', 'e', 'j', 'o', 'a', 'A', 'u', 'w', 'U', 'V', 'W', 'J', 'K', 'i':
+		return 3
+	case 'p', 'P':
+		return 4
+	case 'H', 'k', 'I', 'l':
+		return 5
+	case 'M':
+		return 6
+	case 'S', 's':
+		return 7
+	case 'L', 'f', 'N', 'Q':
+		return 8
+	}
+	return -1
+}
+
+type intervalToken struct {
+	text  string // a literal (escaped), or a single directive
+	field int    // the field of a directive, or -1
+	lit   bool
+}
+
+// intervalTokens splits a format into literals and directives,
+// expanding combinations, and keeping ordinal suffixes
+// with the preceding directive.
+func intervalTokens(fmt string) []intervalToken {
+	var tokens []intervalToken
+	var parser parser
+
+	parser.literal = func(b byte) error {
+		s := string([]byte{b})
+		if b == '%' {
+			s = "%%"
+		}
+		if n := len(tokens) - 1; n >= 0 && tokens[n].lit {
+			tokens[n].text += s
+		} else {
+			tokens = append(tokens, intervalToken{text: s, field: -1, lit: true})
+		}
+		return nil
+	}
+
+	parser.format = func(spec, flag byte) error {
+		if fmt := combination(spec); fmt != "" {
+			return parser.parse(fmt)
+		}
+		it := item{spec: spec, flag: flag, mod: parser.modifier, width: parser.width}
+		if n := len(tokens) - 1; spec == 'o' && n >= 0 && !tokens[n].lit {
+			tokens[n].text += it.String()
+			return nil
+		}
+		tokens = append(tokens, intervalToken{text: it.String(), field: directiveField(spec, flag, it.mod)})
+		return nil
+	}
+
+	parser.parse(fmt)
+	return tokens
+}
+
+// repeatedField returns the index of the first directive
+// for a field that already occurred, or len(tokens) if none.
+func repeatedField(tokens []intervalToken) int {
+	var seen [len(intervalFields)]bool
+	for i, tok := range tokens {
+		if tok.field < 0 {
+			continue
+		}
+		if seen[tok.field] {
+			return i
+		}
+		seen[tok.field] = true
+	}
+	return len(tokens)
+}
+
+func joinTokens(tokens []intervalToken) string {
+	var buf strings.Builder
+	for _, tok := range tokens {
+		buf.WriteString(tok.text)
+	}
+	return buf.String()
+}
@@ -0,0 +1,79 @@
+package strftime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestFormatInterval(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2024, month, day, hour, min, 0, 0, cet)
+	}
+
+	tests := []struct {
+		format     string
+		start, end time.Time
+		want       string
+	}{
+		{"%b %-d, %Y", at(3, 3, 0, 0), at(3, 5, 0, 0), "Mar 3–5, 2024"},
+		{"%b %-d, %Y", at(3, 3, 0, 0), at(4, 5, 0, 0), "Mar 3 – Apr 5, 2024"},
+		{"%b %-d, %Y", at(3, 3, 0, 0), time.Date(2025, 4, 5, 0, 0, 0, 0, cet), "Mar 3, 2024 – Apr 5, 2025"},
+		{"%b %-d, %Y", at(3, 3, 10, 0), at(3, 3, 12, 0), "Mar 3, 2024"},
+		{"%H:%M %Z", at(3, 3, 10, 0), at(3, 3, 11, 30), "10:00–11:30 CET"},
+		{"%H:%M %Z", at(3, 3, 10, 0), at(3, 4, 11, 30), "10:00–11:30 CET"},
+		{"%-I:%M %p", at(3, 3, 10, 0), at(3, 3, 11, 30), "10:00–11:30 AM"},
+		{"%-I:%M %p", at(3, 3, 10, 0), at(3, 3, 14, 0), "10:00 AM – 2:00 PM"},
+		{"%a, %b %-d", at(3, 3, 0, 0), at(3, 5, 0, 0), "Sun, Mar 3 – Tue, Mar 5"},
+		{"%B %-d%o", at(3, 1, 0, 0), at(3, 3, 0, 0), "March 1st–3rd"},
+		{"%F", at(3, 3, 0, 0), at(3, 5, 0, 0), "2024-03-03–05"},
+		{"%F %R", at(3, 3, 10, 0), at(3, 3, 11, 30), "2024-03-03 10:00–11:30"},
+		{"%F %R", at(3, 3, 10, 0), at(3, 5, 11, 30), "2024-03-03 10:00 – 2024-03-05 11:30"},
+		{"%H:%M %z", at(3, 3, 10, 0), time.Date(2024, 3, 3, 11, 30, 0, 0, time.UTC), "10:00 +0100 – 11:30 +0000"},
+		{"100%% %H:%M", at(3, 3, 10, 0), at(3, 3, 11, 30), "100% 10:00–11:30"},
+	}
+
+	for _, test := range tests {
+		if got := strftime.FormatInterval(test.format, test.start, test.end); got != test.want {
+			t.Errorf("FormatInterval(%q, %v, %v) = %q, want %q", test.format, test.start, test.end, got, test.want)
+		}
+	}
+}
+
+func TestLocale_FormatInterval(t *testing.T) {
+	const format = "%-d. %B %Y"
+	german := &strftime.Locale{
+		Months: [12]string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember",
+		},
+		IntervalSeparators: [2]string{"–", " bis "},
+		IntervalFormats: map[string]map[byte]string{
+			format: {'d': "%-d.–%-d. %B %Y"},
+		},
+	}
+	start := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		end  time.Time
+		want string
+	}{
+		{start.AddDate(0, 0, 2), "3.–5. März 2024"},
+		{start.AddDate(0, 1, 2), "3. März bis 5. April 2024"},
+		{start.AddDate(1, 1, 2), "3. März 2024 bis 5. April 2025"},
+	}
+
+	for _, test := range tests {
+		if got := german.FormatInterval(format, start, test.end); got != test.want {
+			t.Errorf("FormatInterval(%q, %v, %v) = %q, want %q", format, start, test.end, got, test.want)
+		}
+	}
+
+	heisei := time.Date(2019, 4, 30, 0, 0, 0, 0, time.UTC)
+	reiwa := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	if got := strftime.Japanese.FormatInterval("%EY%-m月%-d日", heisei, reiwa); got != "平成31年4月30日–令和元年5月1日" {
+		t.Errorf("FormatInterval() = %q", got)
+	}
+}
@@ -0,0 +1,35 @@
+package strftime
+
+import (
+	"sort"
+	"time"
+)
+
+// LeapSeconds are the leap seconds inserted in UTC since 1972,
+// as the midnights UTC they end at.
+// It can be used as the LeapSeconds of a locale,
+// and needs updating if further leap seconds are announced.
+var LeapSeconds = []time.Time{
+	leapDay(1972, time.July), leapDay(1973, time.January), leapDay(1974, time.January),
+	leapDay(1975, time.January), leapDay(1976, time.January), leapDay(1977, time.January),
+	leapDay(1978, time.January), leapDay(1979, time.January), leapDay(1980, time.January),
+	leapDay(1981, time.July), leapDay(1982, time.July), leapDay(1983, time.July),
+	leapDay(1985, time.July), leapDay(1988, time.January), leapDay(1990, time.January),
+	leapDay(1991, time.January), leapDay(1992, time.July), leapDay(1993, time.July),
+	leapDay(1994, time.July), leapDay(1996, time.January), leapDay(1997, time.July),
+	leapDay(1999, time.January), leapDay(2006, time.January), leapDay(2009, time.January),
+	leapDay(2012, time.July), leapDay(2015, time.July), leapDay(2017, time.January),
+}
+
+func leapDay(year int, month time.Month) time.Time {
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// inLeapSecond reports if t falls in the second after a leap second,
+// which is where time.Time, like POSIX time, puts the leap second.
+func (l *Locale) inLeapSecond(t time.Time) bool {
+	i := sort.Search(len(l.LeapSeconds), func(i int) bool {
+		return l.LeapSeconds[i].After(t)
+	})
+	return i > 0 && t.Sub(l.LeapSeconds[i-1]) < time.Second
+}
@@ -0,0 +1,40 @@
+package strftime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestLocale_LeapSeconds(t *testing.T) {
+	l := &strftime.Locale{LeapSeconds: strftime.LeapSeconds}
+
+	tests := []struct {
+		time time.Time
+		want string
+	}{
+		{time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), "2016-12-31 23:59:59 1483228799"},
+		{time.Date(2017, 1, 1, 0, 0, 0, 500000000, time.UTC), "2016-12-31 23:59:60 1483228800"},
+		{time.Date(2017, 1, 1, 0, 0, 1, 0, time.UTC), "2017-01-01 00:00:01 1483228801"},
+		{time.Date(2015, 7, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*3600)), "2015-07-01 08:59:60 1435708800"},
+		{time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC), "2016-07-01 00:00:00 1467331200"},
+		{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), "1972-01-01 00:00:00 63072000"},
+	}
+
+	for _, test := range tests {
+		if got := l.Format("%F %T %s", test.time); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.time, got, test.want)
+		}
+	}
+
+	if got := strftime.Format("%T", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)); got != "00:00:00" {
+		t.Errorf("Format() = %q", got)
+	}
+
+	p := strftime.Parser{LeapSecond: strftime.RollLeapSecond}
+	want := time.Date(2017, 1, 1, 0, 0, 0, 250000000, time.UTC)
+	if got, err := p.Parse("%F %T.%L", l.Format("%F %T.%L", want)); err != nil || !got.Equal(want) {
+		t.Errorf("Parse() = (%v, %v), want %v", got, err, want)
+	}
+}
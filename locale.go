@@ -0,0 +1,447 @@
+package strftime
+
+import (
+	"strings"
+	"time"
+)
+
+// A Locale holds the locale-specific rules
+// used to format and parse times.
+type Locale struct {
+	// FirstDay is the first day of the week.
+	FirstDay time.Weekday
+	// MinDays is the minimal number of days in the first week of the year (1..7).
+	// Days before the first week are in the last week of the previous year.
+	MinDays int
+
+	// Month and weekday names, used for formatting and parsing.
+	// Empty names default to English.
+	Months      [12]string // full month names (%B), as used in dates
+	ShortMonths [12]string // abbreviated month names (%b)
+	Days        [7]string  // full weekday names (%A), from Sunday
+	ShortDays   [7]string  // abbreviated weekday names (%a), from Sunday
+
+	// DayPeriods are the AM and PM designators (%p),
+	// lowercased by %P. If empty, AM and PM are used.
+	DayPeriods [2]string
+
+	// AltNames are alternative spellings of names, accepted when parsing,
+	// mapped to the name they stand for (e.g. "Sept" to "Sep").
+	// English alternatives (Sept, Tues, Thur, Thurs, a.m., p.m.) are always accepted.
+	// Parsing ignores case, and a trailing dot after abbreviations.
+	AltNames map[string]string
+
+	// AltMonths and ShortAltMonths are the standalone (nominative) month names
+	// (%OB, %Ob), for languages where these differ from those used in dates.
+	// If empty, Months and ShortMonths are used.
+	AltMonths      [12]string
+	ShortAltMonths [12]string
+
+	// Ordinal returns the ordinal suffix of n (e.g. "st" for 1).
+	// If nil, English suffixes are used.
+	Ordinal func(n int) string
+
+	// EraNames are the names of the eras before and after year 1.
+	// If empty, BC and AD are used.
+	EraNames [2]string
+
+	// Eras are the alternative eras used by %EC, %Ey and %EY,
+	// sorted by start date.
+	// Without eras, or before the first era,
+	// %EC, %Ey and %EY are equivalent to %C, %y and %Y.
+	Eras []Era
+	// EraYear is the format of %EY, using %EC and %Ey (e.g. "%EC%Ey年").
+	// If empty, "%EC %Ey" is used.
+	EraYear string
+	// FirstEraYear, if not empty, replaces the year of the era
+	// for the first year of an era in %EY (e.g. "元").
+	FirstEraYear string
+
+	// AltDigits are the alternative digits used by the O modifier (e.g. %Od),
+	// and accepted when parsing numbers.
+	AltDigits Digits
+	// UseAltDigits uses the alternative digits for all numbers.
+	UseAltDigits bool
+
+	// Calendar is the calendar system used for years, months,
+	// and days of the month and year.
+	// If nil, the proleptic Gregorian calendar is used.
+	Calendar Calendar
+
+	// IntervalSeparators separate the ends of intervals (FormatInterval)
+	// when their differing parts have no spaces, and when they do.
+	// If empty, "–" and " – " are used.
+	IntervalSeparators [2]string
+	// IntervalFormats override how intervals are formatted.
+	// They are keyed by format, then by the greatest field
+	// that differs between the ends of the interval:
+	// 'E' era, 'Y' year, 'm' month, 'd' day, 'p' day period,
+	// 'H' hour, 'M' minute, 'S' second, 'N' fraction of the second.
+	// The end of the interval is formatted from the first field that repeats
+	// (e.g. "%-d. %B – %-d. %B %Y" for 'm', given "%-d. %B %Y").
+	IntervalFormats map[string]map[byte]string
+
+	// LeapSeconds are the ends of leap seconds, sorted (e.g. LeapSeconds).
+	// Times in the second that follows each of them,
+	// where time.Time puts the leap second, are formatted as second 60
+	// of the previous minute (e.g. 23:59:60).
+	LeapSeconds []time.Time
+}
+
+// An Era is an alternative era, which counts years
+// starting with 1 in the year the era starts.
+type Era struct {
+	Name  string    // the name of the era
+	Start time.Time // the first day of the era
+}
+
+// The default locale uses ISO 8601 week rules.
+var defaultLocale = Locale{
+	FirstDay: time.Monday,
+	MinDays:  4,
+}
+
+// Format is like the package level Format, but uses the locale.
+func (l *Locale) Format(fmt string, t time.Time) string {
+	buf := buffer(fmt)
+	return string(l.AppendFormat(buf, fmt, t))
+}
+
+// AppendFormat is like the package level AppendFormat, but uses the locale.
+func (l *Locale) AppendFormat(dst []byte, fmt string, t time.Time) []byte {
+	dst, _ = appendFormat(dst, fmt, t, l, hasAll)
+	return dst
+}
+
+// Parse is like the package level Parse, but uses the locale.
+func (l *Locale) Parse(fmt, value string) (time.Time, error) {
+	p := Parser{Locale: l}
+	return p.Parse(fmt, value)
+}
+
+// ParsePrefix is like the package level ParsePrefix, but uses the locale.
+func (l *Locale) ParsePrefix(fmt, s string) (t time.Time, n int, err error) {
+	p := Parser{Locale: l}
+	return p.ParsePrefix(fmt, s)
+}
+
+func (l *Locale) firstDay() time.Weekday {
+	return (l.FirstDay%7 + 7) % 7
+}
+
+func (l *Locale) minDays() int {
+	switch {
+	case l.MinDays < 1:
+		return 1
+	case l.MinDays > 7:
+		return 7
+	}
+	return l.MinDays
+}
+
+func (l *Locale) eraNames() []string {
+	if l.EraNames == [2]string{} {
+		return []string{"BC", "AD"}
+	}
+	return l.EraNames[:]
+}
+
+func (l *Locale) intervalSeparators() []string {
+	if l.IntervalSeparators == [2]string{} {
+		return []string{"–", " – "}
+	}
+	return l.IntervalSeparators[:]
+}
+
+func (l *Locale) calendar() Calendar {
+	if l.Calendar == nil {
+		return gregorian{}
+	}
+	return l.Calendar
+}
+
+// date returns the year, month, and day in which t occurs.
+func (l *Locale) date(t time.Time) (year, month, day int) {
+	if l.Calendar == nil {
+		y, m, d := t.Date()
+		return y, int(m), d
+	}
+	return l.Calendar.Date(t)
+}
+
+// yearDay returns the day of the year in which t occurs.
+func (l *Locale) yearDay(t time.Time) int {
+	if l.Calendar == nil {
+		return t.YearDay()
+	}
+	year, _, _ := l.Calendar.Date(t)
+	return epochDays(t) - epochDays(l.Calendar.Time(year, 1, 1)) + 1
+}
+
+// gregorianMonths reports if the calendar has Gregorian months,
+// named by the locale.
+func (l *Locale) gregorianMonths() bool {
+	switch l.Calendar.(type) {
+	case nil, gregorian, buddhist:
+		return true
+	}
+	return false
+}
+
+// monthName returns the name of the month of the year,
+// abbreviated, or in its standalone form, if alt is set.
+func (l *Locale) monthName(year, month int, abbr, alt bool) string {
+	if !l.gregorianMonths() {
+		return l.Calendar.MonthName(year, month, abbr)
+	}
+	return l.months(abbr, alt)[month-1]
+}
+
+func (l *Locale) months(abbr, alt bool) []string {
+	switch {
+	case alt && abbr && l.ShortAltMonths[0] != "":
+		return l.ShortAltMonths[:]
+	case alt && !abbr && l.AltMonths[0] != "":
+		return l.AltMonths[:]
+	case abbr && l.ShortMonths[0] != "":
+		return l.ShortMonths[:]
+	case !abbr && l.Months[0] != "":
+		return l.Months[:]
+	case abbr:
+		return shortMonthNames
+	}
+	return longMonthNames
+}
+
+// monthNames returns the month names matched when parsing,
+// in both forms: the month of the i-th name is i%12 + 1.
+func (l *Locale) monthNames(abbr bool) []string {
+	names := l.months(abbr, false)
+	if alt := l.months(abbr, true); &alt[0] != &names[0] {
+		names = append(names[:12:12], alt...)
+	}
+	return names
+}
+
+func (l *Locale) days(abbr bool) []string {
+	switch {
+	case abbr && l.ShortDays[0] != "":
+		return l.ShortDays[:]
+	case !abbr && l.Days[0] != "":
+		return l.Days[:]
+	case abbr:
+		return shortDayNames
+	}
+	return longDayNames
+}
+
+func (l *Locale) dayPeriods() []string {
+	if l.DayPeriods == [2]string{} {
+		return []string{"AM", "PM"}
+	}
+	return l.DayPeriods[:]
+}
+
+var englishAltNames = map[string]string{
+	"Sept":  "Sep",
+	"Tues":  "Tue",
+	"Thur":  "Thu",
+	"Thurs": "Thu",
+	"a.m.":  "AM",
+	"p.m.":  "PM",
+}
+
+// lookupName matches the longest name, or alternative spelling, that starts s,
+// ignoring case, and the first of names if several match.
+// Abbreviations also match without, or followed by, a trailing dot,
+// unless dot is false.
+func (l *Locale) lookupName(s string, names []string, abbr, dot bool) (index int, rest string, ok bool) {
+	index, n := -1, 0
+	match := func(name string, i int) {
+		if len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) &&
+			(index < 0 || len(name) > n || len(name) == n && i < index) {
+			index, n = i, len(name)
+		}
+	}
+	for i, name := range names {
+		match(name, i)
+		if abbr && strings.HasSuffix(name, ".") {
+			match(name[:len(name)-1], i)
+		}
+	}
+	for _, m := range []map[string]string{l.AltNames, englishAltNames} {
+		for spelling, name := range m {
+			for i, other := range names {
+				if other == name {
+					match(spelling, i)
+				}
+			}
+		}
+	}
+
+	if index < 0 {
+		return 0, s, false
+	}
+	rest = s[n:]
+	if abbr && dot && strings.HasPrefix(rest, ".") && !strings.HasSuffix(s[:n], ".") {
+		rest = rest[1:]
+	}
+	return index, rest, true
+}
+
+// era returns the era (0 before year 1, 1 after) and year of the era.
+func era(year int) (era, yoe int) {
+	if year < 1 {
+		return 0, 1 - year
+	}
+	return 1, year
+}
+
+func (l *Locale) eraYear() string {
+	if l.EraYear == "" {
+		return "%EC %Ey"
+	}
+	return l.EraYear
+}
+
+// altEra returns the alternative era in which t occurs, and the year of the era.
+func (l *Locale) altEra(t time.Time) (era, yoe int, ok bool) {
+	days := epochDays(t)
+	for i := len(l.Eras) - 1; i >= 0; i-- {
+		if start := l.Eras[i].Start; days >= epochDays(start) {
+			return i, t.Year() - start.Year() + 1, true
+		}
+	}
+	return 0, 0, false
+}
+
+// inAltEra reports if t is in the alternative era i.
+func (l *Locale) inAltEra(t time.Time, i int) bool {
+	era, _, ok := l.altEra(t)
+	return ok && era == i
+}
+
+func (l *Locale) altEraNames() []string {
+	names := make([]string, len(l.Eras))
+	for i, e := range l.Eras {
+		names[i] = e.Name
+	}
+	return names
+}
+
+// altDigits reports if alternative digits are used
+// for a directive with the given modifier.
+func (l *Locale) altDigits(mod, spec byte) bool {
+	return (mod == 'O' || l.UseAltDigits) && spec != 'Z' && l.AltDigits != Digits{}
+}
+
+func (l *Locale) ordinal(n int) string {
+	if l.Ordinal != nil {
+		return l.Ordinal(n)
+	}
+	return englishOrdinal(n)
+}
+
+func englishOrdinal(n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch n % 100 {
+	case 11, 12, 13:
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// weekday returns the local day of the week (1..7).
+func (l *Locale) weekday(w time.Weekday) int {
+	return (int(w)-int(l.firstDay())+7)%7 + 1
+}
+
+// week returns the locale week-based year and week number in which t occurs.
+func (l *Locale) week(t time.Time) (year, week int) {
+	year = t.Year()
+	day := t.YearDay() - 1
+	week = weekNumber(day, t.Weekday(), l.firstDay(), l.minDays())
+
+	if week == 0 {
+		year--
+		day += daysIn(year)
+		week = weekNumber(day, t.Weekday(), l.firstDay(), l.minDays())
+		return year, week
+	}
+
+	// The week may be the first week of the next year.
+	last := day + 7 - l.weekday(t.Weekday())
+	if next := last - daysIn(year) + 1; next >= l.minDays() {
+		return year + 1, 1
+	}
+	return year, week
+}
+
+// yearWeek returns the week of the calendar year in which t occurs,
+// for weeks starting on first; days before the first such day are in week 0.
+func (l *Locale) yearWeek(t time.Time, first time.Weekday) int {
+	return weekNumber(l.yearDay(t)-1, t.Weekday(), first, 7)
+}
+
+// yearStart returns the first day of the calendar year.
+func (l *Locale) yearStart(year int) time.Time {
+	return l.calendar().Time(year, 1, 1)
+}
+
+// monthWeek returns the week of the month of a day of the month
+// that falls on weekday wday.
+func (l *Locale) monthWeek(day int, wday time.Weekday) int {
+	return weekNumber(day-1, wday, l.firstDay(), l.minDays())
+}
+
+// weekStart returns the first day of the week of the locale week-based year.
+func (l *Locale) weekStart(year int) time.Time {
+	return firstWeek(year, l.firstDay(), l.minDays())
+}
+
+// weekNumber returns the week number of the zero-based day of a period
+// that falls on weekday wday, for weeks starting on first,
+// and a first week with at least min days.
+// Days before the first week are in week 0.
+func weekNumber(day int, wday, first time.Weekday, min int) int {
+	// The position of the first day of the period in its week.
+	pos := ((int(wday)-int(first)-day)%7 + 7) % 7
+	week := (day + pos) / 7
+	if 7-pos >= min {
+		week++
+	}
+	return week
+}
+
+// firstWeek returns the first day of week 1 of the year,
+// for weeks starting on first, and a first week with at least min days.
+func firstWeek(year int, first time.Weekday, min int) time.Time {
+	return weekOne(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), first, min)
+}
+
+// weekOne returns the first day of week 1 of a period that starts on day,
+// for weeks starting on first, and a first week with at least min days.
+func weekOne(day time.Time, first time.Weekday, min int) time.Time {
+	pos := (int(day.Weekday()) - int(first) + 7) % 7
+	if 7-pos < min {
+		pos -= 7
+	}
+	return day.AddDate(0, 0, -pos)
+}
+
+func daysIn(year int) int {
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		return 366
+	}
+	return 365
+}
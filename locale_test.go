@@ -0,0 +1,411 @@
+package strftime_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestLocale_Week(t *testing.T) {
+	iso := &strftime.Locale{FirstDay: time.Monday, MinDays: 4}
+	us := &strftime.Locale{FirstDay: time.Sunday, MinDays: 1}
+	me := &strftime.Locale{FirstDay: time.Saturday, MinDays: 1}
+	odd := &strftime.Locale{FirstDay: -1, MinDays: 1} // Saturday
+
+	tests := []struct {
+		locale *strftime.Locale
+		time   time.Time
+		want   string
+	}{
+		{iso, time.Date(2008, 12, 29, 0, 0, 0, 0, time.UTC), "2009-W01-1"},
+		{iso, time.Date(2010, 1, 3, 0, 0, 0, 0, time.UTC), "2009-W53-7"},
+		{us, time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC), "2024-W52-7"},
+		{us, time.Date(2024, 12, 29, 0, 0, 0, 0, time.UTC), "2025-W01-1"},
+		{us, time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), "2025-W01-7"},
+		{us, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), "2025-W02-1"},
+		{me, time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC), "2024-W52-7"},
+		{me, time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC), "2025-W01-1"},
+		{me, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), "2025-W01-7"},
+		{odd, time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC), "2025-W01-1"},
+		{odd, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), "2025-W01-7"},
+	}
+
+	for _, test := range tests {
+		if got := test.locale.Format("%:G-W%:V-%:u", test.time); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.time, got, test.want)
+		}
+		if got, err := test.locale.Parse("%:G-W%:V-%:u", test.want); err != nil {
+			t.Errorf("Parse(%q) = %v", test.want, err)
+		} else if !got.Equal(test.time) {
+			t.Errorf("Parse(%q) = %v, want %v", test.want, got, test.time)
+		}
+	}
+}
+
+func TestLocale_ISOWeek(t *testing.T) {
+	base := time.Date(1999, 12, 1, 0, 0, 0, 0, time.UTC)
+	for d := 0; d < 10*366; d++ {
+		date := base.AddDate(0, 0, d)
+		iso := strftime.Format("%G %g %V %u", date)
+		if got := strftime.Format("%:G %:g %:V %:u", date); got != iso {
+			t.Fatalf("Format(%v) = %q, want %q", date, got, iso)
+		}
+	}
+}
+
+func TestLocale_ParseWeek(t *testing.T) {
+	locales := []strftime.Locale{
+		{FirstDay: time.Sunday, MinDays: 1},
+		{FirstDay: time.Monday, MinDays: 4},
+		{FirstDay: time.Saturday, MinDays: 1},
+		{FirstDay: time.Friday, MinDays: 7},
+	}
+	formats := []string{
+		"%:G-%:V-%:u",
+		"%G-%V-%u",
+		"%Y-%U-%w",
+		"%Y-%W-%a",
+		"%Y-%j",
+	}
+
+	base := time.Date(1999, 12, 1, 0, 0, 0, 0, time.UTC)
+	for _, locale := range locales {
+		for d := 0; d < 3*366; d++ {
+			date := base.AddDate(0, 0, d)
+			for _, format := range formats {
+				value := locale.Format(format, date)
+				if got, err := locale.Parse(format, value); err != nil {
+					t.Fatalf("Parse(%q, %q) = %v", format, value, err)
+				} else if !got.Equal(date) {
+					t.Fatalf("Parse(%q, %q) = %v, want %v", format, value, got, date)
+				}
+			}
+		}
+	}
+}
+
+func TestLocale_UTS35(t *testing.T) {
+	if got, err := strftime.UTS35("%:G-W%:V-%:u"); err != nil {
+		t.Error(err)
+	} else if want := "YYYY-'W'ww-e"; got != want {
+		t.Errorf("UTS35() = %q, want %q", got, want)
+	}
+}
+
+func TestLocale_Ordinal(t *testing.T) {
+	french := &strftime.Locale{
+		Ordinal: func(n int) string {
+			if n == 1 {
+				return "er"
+			}
+			return ""
+		},
+	}
+
+	tests := []struct {
+		time time.Time
+		want string
+	}{
+		{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "1er 03 2024"},
+		{time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), "2 03 2024"},
+	}
+
+	for _, test := range tests {
+		if got := french.Format("%-d%o %m %Y", test.time); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.time, got, test.want)
+		}
+		if got, err := french.Parse("%-d%o %m %Y", test.want); err != nil {
+			t.Errorf("Parse(%q) = %v", test.want, err)
+		} else if !got.Equal(test.time) {
+			t.Errorf("Parse(%q) = %v, want %v", test.want, got, test.time)
+		}
+	}
+}
+
+func TestLocale_MonthWeek(t *testing.T) {
+	us := &strftime.Locale{FirstDay: time.Sunday, MinDays: 1}
+	iso := &strftime.Locale{FirstDay: time.Monday, MinDays: 4}
+
+	tests := []struct {
+		locale *strftime.Locale
+		time   time.Time
+		want   string
+	}{
+		{us, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "W1 F1 Friday"},
+		{us, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), "W2 F1 Sunday"},
+		{us, time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), "W3 F2 Tuesday"},
+		{us, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), "W6 F5 Sunday"},
+		{iso, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "W0 F1 Friday"},
+		{iso, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), "W1 F1 Monday"},
+		{iso, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), "W4 F5 Sunday"},
+	}
+
+	for _, test := range tests {
+		if got := test.locale.Format("W%:W F%:w %A", test.time); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.time, got, test.want)
+		}
+	}
+
+	base := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	for _, locale := range []*strftime.Locale{us, iso} {
+		for d := 0; d < 400; d++ {
+			date := base.AddDate(0, 0, d)
+			for _, format := range []string{"%Y %B, the %:w%o %A", "%Y-%m W%:W-%:u"} {
+				value := locale.Format(format, date)
+				if got, err := locale.Parse(format, value); err != nil {
+					t.Fatalf("Parse(%q, %q) = %v", format, value, err)
+				} else if !got.Equal(date) {
+					t.Fatalf("Parse(%q, %q) = %v, want %v", format, value, got, date)
+				}
+			}
+		}
+	}
+
+	if _, err := us.Parse("%Y %B, the %:w%o %A", "2024 February, the 5th Friday"); err == nil {
+		t.Error("want error")
+	}
+	if got, err := strftime.UTS35("W%:W F%:w"); err != nil || got != "'W'W 'F'F" {
+		t.Errorf("UTS35() = (%q, %v)", got, err)
+	}
+}
+
+func TestLocale_Era(t *testing.T) {
+	common := &strftime.Locale{EraNames: [2]string{"BCE", "CE"}}
+
+	base := time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC)
+	if got := common.Format("%:y %:C", base); got != "44 BCE" {
+		t.Errorf("Format() = %q", got)
+	}
+	if got, err := common.Parse("%:y %:C", "44 bce"); err != nil {
+		t.Error(err)
+	} else if got.Year() != -43 {
+		t.Errorf("Parse() = %v", got)
+	}
+	if got, err := common.Parse("%:y %:C", "44 BC"); err == nil {
+		t.Errorf("Parse() = %v", got)
+	}
+}
+
+func TestLocale_JapaneseEra(t *testing.T) {
+	tests := []struct {
+		time time.Time
+		want string
+	}{
+		{time.Date(1912, 7, 29, 0, 0, 0, 0, time.UTC), "明治45年7月29日 明治 45"},
+		{time.Date(1912, 7, 30, 0, 0, 0, 0, time.UTC), "大正元年7月30日 大正 1"},
+		{time.Date(1989, 1, 7, 0, 0, 0, 0, time.UTC), "昭和64年1月7日 昭和 64"},
+		{time.Date(1989, 1, 8, 0, 0, 0, 0, time.UTC), "平成元年1月8日 平成 1"},
+		{time.Date(2019, 4, 30, 0, 0, 0, 0, time.UTC), "平成31年4月30日 平成 31"},
+		{time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC), "令和元年5月1日 令和 1"},
+		{time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC), "令和6年6月15日 令和 6"},
+	}
+
+	for _, test := range tests {
+		if got := strftime.Japanese.Format("%EY%-m月%-d日 %EC %Ey", test.time); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.time, got, test.want)
+		}
+		if got, err := strftime.Japanese.Parse("%EY%-m月%-d日 %EC %Ey", test.want); err != nil {
+			t.Errorf("Parse(%q) = %v", test.want, err)
+		} else if !got.Equal(test.time) {
+			t.Errorf("Parse(%q) = %v, want %v", test.want, got, test.time)
+		}
+	}
+
+	if got, err := strftime.Japanese.Parse("%EC%Ey年%m月%d日", "令和6年03月01日"); err != nil {
+		t.Error(err)
+	} else if want := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Parse() = %v, want %v", got, want)
+	}
+
+	// Dates outside the era are only rejected when validating.
+	p := strftime.Parser{Locale: strftime.Japanese, Validate: true}
+	for _, value := range []string{"平成31年5月1日", "令和元年4月30日"} {
+		if got, err := p.Parse("%EY%-m月%-d日", value); err == nil {
+			t.Errorf("Parse(%q) = %v", value, got)
+		}
+		if _, err := strftime.Japanese.Parse("%EY%-m月%-d日", value); err != nil {
+			t.Errorf("Parse(%q) = %v", value, err)
+		}
+	}
+	if _, err := p.Parse("%EY%-m月%-d日", "平成31年4月30日"); err != nil {
+		t.Error(err)
+	}
+
+	// Before the first era, and without eras.
+	if got := strftime.Japanese.Format("%EY %EC %Ey", time.Date(1868, 1, 1, 0, 0, 0, 0, time.UTC)); got != "1868 18 68" {
+		t.Errorf("Format() = %q", got)
+	}
+	if got := strftime.Format("%EY %EC %Ey", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); got != "2024 20 24" {
+		t.Errorf("Format() = %q", got)
+	}
+	for _, date := range []time.Time{
+		time.Date(1600, 10, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1868, 10, 22, 0, 0, 0, 0, time.UTC),
+	} {
+		for _, format := range []string{"%EY %m %d", "%EC %Ey %m %d", "%Oe %Ob %EY"} {
+			value := strftime.Japanese.Format(format, date)
+			for _, parse := range []func(string, string) (time.Time, error){strftime.Japanese.Parse, p.Parse} {
+				if got, err := parse(format, value); err != nil {
+					t.Errorf("Parse(%q, %q) = %v", format, value, err)
+				} else if !got.Equal(date) {
+					t.Errorf("Parse(%q, %q) = %v, want %v", format, value, got, date)
+				}
+			}
+		}
+	}
+
+	// Future eras.
+	locale := *strftime.Japanese
+	locale.Eras = append(locale.Eras[:len(locale.Eras):len(locale.Eras)],
+		strftime.Era{Name: "未来", Start: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if got := locale.Format("%EY", time.Date(2101, 1, 1, 0, 0, 0, 0, time.UTC)); got != "未来2年" {
+		t.Errorf("Format() = %q", got)
+	}
+}
+
+func TestLocale_Digits(t *testing.T) {
+	arabic := &strftime.Locale{AltDigits: strftime.ArabicIndicDigits}
+	persian := &strftime.Locale{
+		AltDigits:    strftime.PersianDigits,
+		UseAltDigits: true,
+		Calendar:     strftime.SolarHijri,
+	}
+	han := &strftime.Locale{AltDigits: strftime.HanDigits, UseAltDigits: true}
+	arabicAll := &strftime.Locale{AltDigits: strftime.ArabicIndicDigits, UseAltDigits: true}
+	japanese := *strftime.Japanese
+	japanese.AltDigits, japanese.UseAltDigits = strftime.HanDigits, true
+
+	tests := []struct {
+		locale *strftime.Locale
+		format string
+		time   time.Time
+		want   string
+	}{
+		{arabic, "%Od/%Om/%Y %OH:%OM", time.Date(2024, 6, 5, 9, 30, 0, 0, time.UTC), "٠٥/٠٦/2024 ٠٩:٣٠"},
+		{arabic, "%B %Oe%o, %Y", time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), "June  ٢nd, 2024"},
+		{persian, "%Y/%m/%d %H:%M %:z", time.Date(2024, 6, 15, 9, 30, 0, 0, time.FixedZone("", 12600)), "۱۴۰۳/۰۳/۲۶ ۰۹:۳۰ +۰۳:۳۰"},
+		{persian, "%e %B %Y", time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), " ۶ Farvardin ۱۴۰۳"},
+		{han, "%Y年%-m月%-d日", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), "二〇二四年一〇月一日"},
+		{arabicAll, "%EY-%m-%d", time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC), "٢٠٢٤-٠٦-٠٥"},
+		{arabicAll, "%EC %Ey %m %d", time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC), "٢٠ ٢٤ ٠٦ ٠٥"},
+		{&japanese, "%EY%-m月%-d日", time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC), "令和六年六月五日"},
+		{&japanese, "%EY %-m月%-d日", time.Date(1800, 6, 5, 0, 0, 0, 0, time.UTC), "一八〇〇 六月五日"},
+		{&japanese, "%EC %Ey", time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), "一八 〇〇"},
+	}
+
+	for _, test := range tests {
+		if got := test.locale.Format(test.format, test.time); got != test.want {
+			t.Errorf("Format(%q) = %q, want %q", test.format, got, test.want)
+		}
+		if got, err := test.locale.Parse(test.format, test.want); err != nil {
+			t.Errorf("Parse(%q) = %v", test.want, err)
+		} else if !got.Equal(test.time) {
+			t.Errorf("Parse(%q) = %v, want %v", test.want, got, test.time)
+		}
+	}
+
+	// ASCII digits are always accepted.
+	if got, err := arabic.Parse("%Od/%Om/%Y", "05/٠٦/2024"); err != nil {
+		t.Error(err)
+	} else if want := time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Parse() = %v, want %v", got, want)
+	}
+	if _, err := arabic.Parse("%Od/%Om", "٠٥/١٣"); err == nil {
+		t.Error("want error")
+	} else if !strings.Contains(err.Error(), "month out of range") {
+		t.Errorf("Parse() = %v", err)
+	}
+
+	if strftime.DecimalDigits('๐') != strftime.ThaiDigits {
+		t.Error("DecimalDigits")
+	}
+}
+
+func TestLocale_AltMonths(t *testing.T) {
+	tests := []struct {
+		locale *strftime.Locale
+		time   time.Time
+		want   string
+	}{
+		{strftime.Russian, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "1 января 2024, январь, янв, пн"},
+		{strftime.Russian, time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC), "9 мая 2024, май, май, чт"},
+		{strftime.Ukrainian, time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), "8 березня 2024, березень, бер, пт"},
+		{strftime.Polish, time.Date(2024, 11, 11, 0, 0, 0, 0, time.UTC), "11 listopada 2024, listopad, lis, pon"},
+		{strftime.Greek, time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), "25 Μαρτίου 2024, Μάρτιος, Μάρ, Δευ"},
+	}
+
+	for _, test := range tests {
+		if got := test.locale.Format("%-d %B %Y, %OB, %Ob, %a", test.time); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.time, got, test.want)
+		}
+		if got, err := test.locale.Parse("%-d %B %Y, %OB, %Ob, %a", test.want); err != nil {
+			t.Errorf("Parse(%q) = %v", test.want, err)
+		} else if !got.Equal(test.time) {
+			t.Errorf("Parse(%q) = %v, want %v", test.want, got, test.time)
+		}
+	}
+
+	// Both forms are accepted, ignoring case.
+	for _, value := range []string{"9 мая 2024", "9 Май 2024", "9 МАЯ 2024"} {
+		if got, err := strftime.Russian.Parse("%-d %B %Y", value); err != nil {
+			t.Errorf("Parse(%q) = %v", value, err)
+		} else if want := time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("Parse(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	if got := strftime.Format("%OB %Ob %Oh", time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC)); got != "May May May" {
+		t.Errorf("Format() = %q", got)
+	}
+	if got, err := strftime.UTS35("%-d %B, %OB %Ob"); err != nil {
+		t.Error(err)
+	} else if want := "d MMMM, LLLL LLL"; got != want {
+		t.Errorf("UTS35() = %q, want %q", got, want)
+	}
+}
+
+func TestLocale_Names(t *testing.T) {
+	tests := []struct {
+		locale *strftime.Locale
+		format string
+		value  string
+		want   time.Time
+	}{
+		{&strftime.Locale{}, "%d %b %Y", "05 jan 2024", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{&strftime.Locale{}, "%d %b %Y", "05 SEPT 2024", time.Date(2024, 9, 5, 0, 0, 0, 0, time.UTC)},
+		{&strftime.Locale{}, "%d %b %Y", "05 Sept. 2024", time.Date(2024, 9, 5, 0, 0, 0, 0, time.UTC)},
+		{&strftime.Locale{}, "%b.%d.%Y", "Sep.05.2024", time.Date(2024, 9, 5, 0, 0, 0, 0, time.UTC)},
+		{&strftime.Locale{}, "%a %F", "Tues 2024-09-03", time.Date(2024, 9, 3, 0, 0, 0, 0, time.UTC)},
+		{&strftime.Locale{}, "%a %F", "thurs. 2024-09-05", time.Date(2024, 9, 5, 0, 0, 0, 0, time.UTC)},
+		{&strftime.Locale{}, "%F %I:%M %p", "2024-09-05 09:30 p.m.", time.Date(2024, 9, 5, 21, 30, 0, 0, time.UTC)},
+		{&strftime.Locale{}, "%F %I:%M %P", "2024-09-05 12:30 AM", time.Date(2024, 9, 5, 0, 30, 0, 0, time.UTC)},
+		{strftime.French, "%A %-d %B %Y", "MARDI 3 septembre 2024", time.Date(2024, 9, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.French, "%a %-d %b %Y", "mar 3 sept 2024", time.Date(2024, 9, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.French, "%a %-d %b %Y", "mar. 3 sept. 2024", time.Date(2024, 9, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.French, "%-d %b %Y", "3 fév 2024", time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.Russian, "%-d %b %Y", "3 сент. 2024", time.Date(2024, 9, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.Greek, "%F %I:%M %p", "2024-09-05 09:30 μ.μ.", time.Date(2024, 9, 5, 21, 30, 0, 0, time.UTC)},
+		{strftime.Japanese, "%F %p%I時", "2024-09-05 午後9時", time.Date(2024, 9, 5, 21, 0, 0, 0, time.UTC)},
+		// Ties go to the first name.
+		{&strftime.Locale{AltNames: map[string]string{"Ma": "May", "MA": "Mar"}}, "%b %d %Y", "Ma 05 2024", time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if got, err := test.locale.Parse(test.format, test.value); err != nil {
+			t.Errorf("Parse(%q, %q) = %v", test.format, test.value, err)
+		} else if !got.Equal(test.want) {
+			t.Errorf("Parse(%q, %q) = %v, want %v", test.format, test.value, got, test.want)
+		}
+	}
+
+	date := time.Date(2024, 9, 3, 21, 0, 0, 0, time.UTC)
+	if got := strftime.French.Format("%a %-d %b %Y, %A %-d%o %B", date); got != "mar. 3 sept. 2024, mardi 3 septembre" {
+		t.Errorf("Format() = %q", got)
+	}
+	if got := strftime.Greek.Format("%I %p, %I %P", date); got != "09 μ.μ., 09 μ.μ." {
+		t.Errorf("Format() = %q", got)
+	}
+}
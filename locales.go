@@ -0,0 +1,191 @@
+package strftime
+
+import "time"
+
+// French is a French locale.
+var French = &Locale{
+	FirstDay: time.Monday,
+	MinDays:  4,
+	Months: [12]string{
+		"janvier", "février", "mars", "avril", "mai", "juin",
+		"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+	},
+	ShortMonths: [12]string{
+		"janv.", "févr.", "mars", "avr.", "mai", "juin",
+		"juil.", "août", "sept.", "oct.", "nov.", "déc.",
+	},
+	Days: [7]string{
+		"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi",
+	},
+	ShortDays: [7]string{
+		"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam.",
+	},
+	AltNames: map[string]string{
+		"fév":   "févr.",
+		"avril": "avr.",
+	},
+	Ordinal: func(n int) string {
+		if n == 1 {
+			return "er"
+		}
+		return ""
+	},
+	EraNames: [2]string{"av. J.-C.", "ap. J.-C."},
+}
+
+// Greek is a Greek locale.
+var Greek = &Locale{
+	FirstDay: time.Monday,
+	MinDays:  4,
+	Months: [12]string{
+		"Ιανουαρίου", "Φεβρουαρίου", "Μαρτίου", "Απριλίου", "Μαΐου", "Ιουνίου",
+		"Ιουλίου", "Αυγούστου", "Σεπτεμβρίου", "Οκτωβρίου", "Νοεμβρίου", "Δεκεμβρίου",
+	},
+	ShortMonths: [12]string{
+		"Ιαν", "Φεβ", "Μαρ", "Απρ", "Μαΐ", "Ιουν",
+		"Ιουλ", "Αυγ", "Σεπ", "Οκτ", "Νοε", "Δεκ",
+	},
+	AltMonths: [12]string{
+		"Ιανουάριος", "Φεβρουάριος", "Μάρτιος", "Απρίλιος", "Μάιος", "Ιούνιος",
+		"Ιούλιος", "Αύγουστος", "Σεπτέμβριος", "Οκτώβριος", "Νοέμβριος", "Δεκέμβριος",
+	},
+	ShortAltMonths: [12]string{
+		"Ιαν", "Φεβ", "Μάρ", "Απρ", "Μάι", "Ιούν",
+		"Ιούλ", "Αύγ", "Σεπ", "Οκτ", "Νοέ", "Δεκ",
+	},
+	Days: [7]string{
+		"Κυριακή", "Δευτέρα", "Τρίτη", "Τετάρτη", "Πέμπτη", "Παρασκευή", "Σάββατο",
+	},
+	ShortDays: [7]string{
+		"Κυρ", "Δευ", "Τρί", "Τετ", "Πέμ", "Παρ", "Σάβ",
+	},
+	DayPeriods: [2]string{"π.μ.", "μ.μ."},
+	Ordinal:    noOrdinal,
+	EraNames:   [2]string{"π.Χ.", "μ.Χ."},
+}
+
+// Japanese is a Japanese locale, with the eras of the Japanese calendar
+// since the Meiji era (明治).
+// Future eras can be appended to its Eras.
+var Japanese = &Locale{
+	FirstDay: time.Sunday,
+	MinDays:  1,
+	Months: [12]string{
+		"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月",
+	},
+	ShortMonths: [12]string{
+		"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月",
+	},
+	Days: [7]string{
+		"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日",
+	},
+	ShortDays: [7]string{
+		"日", "月", "火", "水", "木", "金", "土",
+	},
+	DayPeriods: [2]string{"午前", "午後"},
+	Ordinal:    noOrdinal,
+	EraNames:   [2]string{"紀元前", "西暦"},
+	Eras: []Era{
+		{"明治", time.Date(1868, time.October, 23, 0, 0, 0, 0, time.UTC)},
+		{"大正", time.Date(1912, time.July, 30, 0, 0, 0, 0, time.UTC)},
+		{"昭和", time.Date(1926, time.December, 25, 0, 0, 0, 0, time.UTC)},
+		{"平成", time.Date(1989, time.January, 8, 0, 0, 0, 0, time.UTC)},
+		{"令和", time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC)},
+	},
+	EraYear:      "%EC%Ey年",
+	FirstEraYear: "元",
+}
+
+// Polish is a Polish locale.
+var Polish = &Locale{
+	FirstDay: time.Monday,
+	MinDays:  4,
+	Months: [12]string{
+		"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca",
+		"lipca", "sierpnia", "września", "października", "listopada", "grudnia",
+	},
+	ShortMonths: [12]string{
+		"sty", "lut", "mar", "kwi", "maj", "cze",
+		"lip", "sie", "wrz", "paź", "lis", "gru",
+	},
+	AltMonths: [12]string{
+		"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec",
+		"lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień",
+	},
+	Days: [7]string{
+		"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota",
+	},
+	ShortDays: [7]string{
+		"nie", "pon", "wto", "śro", "czw", "pią", "sob",
+	},
+	Ordinal:  noOrdinal,
+	EraNames: [2]string{"p.n.e.", "n.e."},
+}
+
+// Russian is a Russian locale.
+var Russian = &Locale{
+	FirstDay: time.Monday,
+	MinDays:  4,
+	Months: [12]string{
+		"января", "февраля", "марта", "апреля", "мая", "июня",
+		"июля", "августа", "сентября", "октября", "ноября", "декабря",
+	},
+	ShortMonths: [12]string{
+		"янв", "фев", "мар", "апр", "мая", "июн",
+		"июл", "авг", "сен", "окт", "ноя", "дек",
+	},
+	AltMonths: [12]string{
+		"январь", "февраль", "март", "апрель", "май", "июнь",
+		"июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь",
+	},
+	ShortAltMonths: [12]string{
+		"янв", "фев", "мар", "апр", "май", "июн",
+		"июл", "авг", "сен", "окт", "ноя", "дек",
+	},
+	Days: [7]string{
+		"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота",
+	},
+	ShortDays: [7]string{
+		"вс", "пн", "вт", "ср", "чт", "пт", "сб",
+	},
+	AltNames: map[string]string{
+		"февр": "фев",
+		"сент": "сен",
+		"нояб": "ноя",
+	},
+	Ordinal:  noOrdinal,
+	EraNames: [2]string{"до н. э.", "н. э."},
+}
+
+// Ukrainian is a Ukrainian locale.
+var Ukrainian = &Locale{
+	FirstDay: time.Monday,
+	MinDays:  1,
+	Months: [12]string{
+		"січня", "лютого", "березня", "квітня", "травня", "червня",
+		"липня", "серпня", "вересня", "жовтня", "листопада", "грудня",
+	},
+	ShortMonths: [12]string{
+		"січ", "лют", "бер", "кві", "тра", "чер",
+		"лип", "сер", "вер", "жов", "лис", "гру",
+	},
+	AltMonths: [12]string{
+		"січень", "лютий", "березень", "квітень", "травень", "червень",
+		"липень", "серпень", "вересень", "жовтень", "листопад", "грудень",
+	},
+	Days: [7]string{
+		"неділя", "понеділок", "вівторок", "середа", "четвер", "пʼятниця", "субота",
+	},
+	ShortDays: [7]string{
+		"нд", "пн", "вт", "ср", "чт", "пт", "сб",
+	},
+	Ordinal:  noOrdinal,
+	EraNames: [2]string{"до н. е.", "н. е."},
+}
+
+// noOrdinal is the ordinal suffix of languages that don't use one after numbers.
+func noOrdinal(n int) string {
+	return ""
+}
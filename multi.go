@@ -0,0 +1,258 @@
+package strftime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseAny is like Parse, but tries each of the formats in order,
+// and returns the index of the first that matches the value.
+// If none matches, the error is a *ParseAnyError with why each of them failed.
+func ParseAny(formats []string, value string) (time.Time, int, error) {
+	return defaultLocale.ParseAny(formats, value)
+}
+
+// ParseAny is like the package level ParseAny, but uses the locale.
+func (l *Locale) ParseAny(formats []string, value string) (time.Time, int, error) {
+	p := Parser{Locale: l}
+	return p.ParseAny(formats, value)
+}
+
+// ParseAny is like the package level ParseAny, but uses the parser options.
+func (p *Parser) ParseAny(formats []string, value string) (time.Time, int, error) {
+	m, err := p.Compile(formats...)
+	if err != nil {
+		return time.Time{}, -1, err
+	}
+	return m.Parse(value)
+}
+
+// Formats are compiled formats, to parse values in any of them.
+// Formats with a common prefix share the work of parsing it.
+// Formats are safe for concurrent use.
+type Formats struct {
+	parser  Parser
+	formats []string
+	root    formatNode
+}
+
+// Compile compiles formats, to parse values in any of them.
+func Compile(formats ...string) (*Formats, error) {
+	return defaultLocale.Compile(formats...)
+}
+
+// Compile is like the package level Compile, but uses the locale.
+func (l *Locale) Compile(formats ...string) (*Formats, error) {
+	p := Parser{Locale: l}
+	return p.Compile(formats...)
+}
+
+// Compile is like the package level Compile, but uses the parser options.
+func (p *Parser) Compile(formats ...string) (*Formats, error) {
+	if len(formats) == 0 {
+		return nil, errorString("strftime: no formats")
+	}
+	m := Formats{
+		parser:  *p,
+		formats: append([]string(nil), formats...),
+	}
+	for i, fmt := range formats {
+		items, err := compile(fmt)
+		if err != nil {
+			return nil, err
+		}
+		m.root.add(items, i)
+	}
+	return &m, nil
+}
+
+// Parse is like ParseAny, but uses the compiled formats and their options.
+func (m *Formats) Parse(value string) (time.Time, int, error) {
+	s := formatsScan{
+		Formats: m,
+		value:   value,
+		match:   len(m.formats),
+		errs:    make([]error, len(m.formats)),
+	}
+	f := m.parser.fields()
+	s.scan(&m.root, &f, value)
+
+	if s.match < len(m.formats) {
+		return s.time, s.match, nil
+	}
+	return time.Time{}, -1, &ParseAnyError{
+		Value:   value,
+		Formats: append([]string(nil), m.formats...),
+		Errs:    s.errs,
+	}
+}
+
+// formatNode is a node of the trie of compiled formats.
+// Each node scans an item, with the items that follow it,
+// which are only looked at, or an optional section, and all that follows it.
+type formatNode struct {
+	key      string // nodeKey of items; empty for sections
+	items    []item
+	first    int   // the first format through the node
+	formats  []int // the formats that end at the node
+	children []*formatNode
+}
+
+func (n *formatNode) add(items []item, format int) {
+	if len(items) == 0 {
+		n.formats = append(n.formats, format)
+		return
+	}
+
+	// Sections look ahead at all the items that follow them,
+	// so these are never shared.
+	if items[0].spec == '[' {
+		c := &formatNode{items: items, first: format}
+		c.add(nil, format)
+		n.children = append(n.children, c)
+		return
+	}
+
+	key := nodeKey(items)
+	for _, c := range n.children {
+		if c.key == key {
+			c.add(items[1:], format)
+			return
+		}
+	}
+	c := &formatNode{key: key, items: items, first: format}
+	c.add(items[1:], format)
+	n.children = append(n.children, c)
+}
+
+// nodeKey identifies the first of items,
+// and how the items that follow it affect scanning it.
+func nodeKey(items []item) string {
+	it := items[0]
+	if it.spec == 0 {
+		return "\x00" + it.lit
+	}
+	var context byte
+	if adjacent(items[1:]) {
+		context |= 1
+	}
+	if fractionFollows(items[1:]) {
+		context |= 2
+	}
+	if len(items) > 1 && strings.HasPrefix(items[1].lit, ".") {
+		context |= 4
+	}
+	return it.String() + string(rune('0'+context))
+}
+
+// formatsScan is the state of parsing a value with compiled formats.
+type formatsScan struct {
+	*Formats
+	value string
+	match int // the first format that matched
+	time  time.Time
+	errs  []error
+}
+
+func (s *formatsScan) scan(n *formatNode, f *fields, value string) {
+	l := s.parser.locale()
+
+	for _, i := range n.formats {
+		if i >= s.match {
+			break
+		}
+		if value != "" {
+			s.errs[i] = s.parseError(&time.ParseError{Message: ": extra text: " + strconv.Quote(value)}, i)
+			continue
+		}
+		g := *f
+		t, err := g.time(l)
+		if err != nil {
+			s.errs[i] = &time.ParseError{
+				Layout:  s.formats[i],
+				Value:   s.value,
+				Message: ": " + err.Error(),
+			}
+			continue
+		}
+		s.match, s.time = i, t
+	}
+
+	for _, c := range n.children {
+		if c.first >= s.match {
+			break
+		}
+		g := *f
+		var rest string
+		var err error
+		if c.items[0].spec == '[' {
+			rest, err = g.scan(c.items, value, l)
+		} else {
+			rest, err = g.scanItem(c.items, value, l)
+		}
+		if err != nil {
+			s.fail(c, err)
+			continue
+		}
+		s.scan(c, &g, rest)
+	}
+}
+
+// fail records the error for the formats through a node.
+func (s *formatsScan) fail(n *formatNode, err error) {
+	for _, i := range n.formats {
+		s.errs[i] = s.parseError(err, i)
+	}
+	for _, c := range n.children {
+		s.fail(c, err)
+	}
+}
+
+func (s *formatsScan) parseError(err error, format int) error {
+	if err, ok := err.(*time.ParseError); ok {
+		e := *err
+		e.Layout = s.formats[format]
+		e.Value = s.value
+		return &e
+	}
+	return err
+}
+
+// ParseAnyError is returned by ParseAny when none of the formats matches a value.
+// Errs holds the error of each of the Formats, in order.
+type ParseAnyError struct {
+	Value   string
+	Formats []string
+	Errs    []error
+}
+
+// Unwrap returns the error of each format.
+func (e *ParseAnyError) Unwrap() []error {
+	return e.Errs
+}
+
+func (e *ParseAnyError) Error() string {
+	var buf strings.Builder
+	buf.WriteString("strftime: no format matches ")
+	buf.WriteString(strconv.Quote(e.Value))
+	for i, err := range e.Errs {
+		if i == 0 {
+			buf.WriteString(": ")
+		} else {
+			buf.WriteString("; ")
+		}
+		buf.WriteString(strconv.Quote(e.Formats[i]))
+		buf.WriteString(": ")
+		if err, ok := err.(*time.ParseError); ok {
+			if err.Message != "" {
+				buf.WriteString(strings.TrimPrefix(err.Message, ": "))
+			} else {
+				buf.WriteString("cannot parse " + strconv.Quote(err.ValueElem) + " as " + strconv.Quote(err.LayoutElem))
+			}
+			continue
+		}
+		buf.WriteString(err.Error())
+	}
+	return buf.String()
+}
@@ -0,0 +1,144 @@
+package strftime_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+var anyFormats = []string{
+	"%Y-%m-%dT%H:%M:%S%z",
+	"%Y-%m-%d %H:%M:%S",
+	"%Y-%m-%d",
+	"%Y%m%d",
+	"%d/%m/%Y",
+	"%m/%d/%Y",
+	"%d %b %Y",
+	"%b %e %Y",
+	"%s",
+}
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		value  string
+		format int
+		want   time.Time
+	}{
+		{"2024-03-03T10:00:01+0100", 0, time.Date(2024, 3, 3, 9, 0, 1, 0, time.UTC)},
+		{"2024-03-03 10:00:01", 1, time.Date(2024, 3, 3, 10, 0, 1, 0, time.UTC)},
+		{"2024-03-03", 2, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"20240303", 3, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"03/04/2024", 4, time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)},
+		{"03/13/2024", 5, time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)},
+		{"3 Mar 2024", 6, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"Mar  3 2024", 7, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"1709460001", 8, time.Unix(1709460001, 0)},
+	}
+
+	m, err := strftime.Compile(anyFormats...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		got, i, err := strftime.ParseAny(anyFormats, test.value)
+		if err != nil {
+			t.Errorf("ParseAny(%q) error = %v", test.value, err)
+		} else if i != test.format || !got.Equal(test.want) {
+			t.Errorf("ParseAny(%q) = (%v, %d), want (%v, %d)", test.value, got, i, test.want, test.format)
+		}
+
+		got, i, err = m.Parse(test.value)
+		if err != nil || i != test.format || !got.Equal(test.want) {
+			t.Errorf("Formats.Parse(%q) = (%v, %d, %v)", test.value, got, i, err)
+		}
+
+		want, err := strftime.Parse(anyFormats[test.format], test.value)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("Parse(%q, %q) = (%v, %v)", anyFormats[test.format], test.value, want, err)
+		}
+	}
+}
+
+func TestParseAny_error(t *testing.T) {
+	_, i, err := strftime.ParseAny(anyFormats, "2024-02-30")
+	if err == nil || i != -1 {
+		t.Fatalf("ParseAny() = (%d, %v)", i, err)
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, `strftime: no format matches "2024-02-30": `) {
+		t.Errorf("ParseAny() error = %v", err)
+	}
+
+	var e *strftime.ParseAnyError
+	if !errors.As(err, &e) || e.Value != "2024-02-30" || len(e.Errs) != len(anyFormats) {
+		t.Fatalf("ParseAny() error = %#v", err)
+	}
+	for i, want := range map[int]string{
+		0: `cannot parse "" as "T"`,
+		2: `day out of range`,
+		6: `cannot parse "24-02-30" as " "`,
+		8: `extra text: "-02-30"`,
+	} {
+		err, ok := e.Errs[i].(*time.ParseError)
+		if !ok || e.Formats[i] != anyFormats[i] || err.Layout != anyFormats[i] || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseAny() error %d = %v, want %q", i, e.Errs[i], want)
+		}
+	}
+
+	if _, _, err := strftime.ParseAny(nil, "2024"); err == nil {
+		t.Error("ParseAny(nil) want error")
+	}
+	if _, err := strftime.Compile("%F", "%:d"); err == nil {
+		t.Error("Compile() want error")
+	}
+}
+
+func TestFormats_Parse(t *testing.T) {
+	formats := []string{
+		"%Y%m%d", "%Y%m", "%Y", "%Y-%m", "%Y-%j", "%Y-%m-%d",
+		"%T", "%T.%L", "%R", "%R%[:%S%]", "%H:%M %p", "%I:%M %p",
+		"%d %b", "%d %b.", "%d %B %Y", "%B %-d%o",
+	}
+	values := []string{
+		"20240303", "202403", "2024", "2024-03", "2024-063", "2024-03-03", "2024-3-3",
+		"10:00:01", "10:00:01.500", "10:00", "13:00 PM", "01:00 PM",
+		"03 Mar", "03 Mar.", "03 March 2024", "March 3rd", "March 3",
+	}
+
+	p := strftime.Parser{Mode: strftime.StrictMode}
+	for _, parser := range []strftime.Parser{{}, p} {
+		m, err := parser.Compile(formats...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, value := range values {
+			got, i, err := m.Parse(value)
+
+			// Each format before the match should fail.
+			want := -1
+			var wantTime time.Time
+			for j, format := range formats {
+				if t, err := parser.Parse(format, value); err == nil {
+					want, wantTime = j, t
+					break
+				}
+			}
+			if i != want || !got.Equal(wantTime) || (err == nil) != (want >= 0) {
+				t.Errorf("Formats.Parse(%q) = (%v, %d, %v), want (%v, %d)", value, got, i, err, wantTime, want)
+			}
+		}
+	}
+}
+
+func BenchmarkFormats_Parse(b *testing.B) {
+	m, err := strftime.Compile(anyFormats...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		m.Parse("Mar  3 2024")
+	}
+}
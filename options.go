@@ -0,0 +1,113 @@
+package strftime
+
+import "time"
+
+// A Parser parses times with options.
+// The zero value parses like the package level functions.
+type Parser struct {
+	// Locale is the locale used for parsing.
+	// If nil, the C locale is used.
+	Locale *Locale
+
+	// Reference, if not zero, provides the fields missing from values:
+	// the year, month, or date, when the format has none of them,
+	// and the zone, which is the location of the reference.
+	Reference time.Time
+	// Closest moves the fields taken from Reference by one period
+	// (e.g. to the previous or next year, for a format without a year),
+	// if that brings the time closer to the reference
+	// (e.g. "Dec 31" read on January 1 is in the previous year).
+	Closest bool
+
+	// TwoDigitYears expands two-digit years (%y without %C, %g, %:g),
+	// given the reference year: the year of Reference, or the current year.
+	// If nil, years 69..99 are 1969..1999, and 00..68 are 2000..2068.
+	TwoDigitYears func(yy, ref int) int
+
+	// Validate checks that fields not needed to determine the time
+	// match it, and returns an error if they don't:
+	// weekdays, days of the year, week numbers and week-based years,
+	// 24-hour clock hours and AM/PM, centuries and years,
+	// and dates and times parsed along with seconds since the epoch.
+	// It also rejects dates outside the alternative era they were parsed in (%EC).
+	Validate bool
+
+	// LeapSecond is the policy for second 60 (%S), which time.Time can't represent.
+	LeapSecond LeapSecond
+
+	// Mode is how strictly values must match the format.
+	Mode Mode
+}
+
+// A Mode is how strictly values must match the format.
+type Mode int
+
+// Parsing modes.
+const (
+	// DefaultMode accepts numbers without their padding,
+	// and literals exactly as in the format.
+	DefaultMode Mode = iota
+	// StrictMode accepts values exactly as formatted:
+	// numbers with their padding, literals exactly as in the format,
+	// and no fractional seconds or dots after names the format lacks.
+	StrictMode
+	// LenientMode follows POSIX strptime:
+	// whitespace in the format matches zero or more whitespace,
+	// whitespace before numbers is skipped,
+	// and numbers need none of their leading zeros.
+	LenientMode
+)
+
+// A LeapSecond is a policy for parsing leap seconds.
+type LeapSecond int
+
+// Leap second policies.
+const (
+	RejectLeapSecond LeapSecond = iota // second 60 is out of range
+	ClampLeapSecond                    // second 60 is 59.999999999
+	RollLeapSecond                     // second 60 is second 0 of the next minute
+)
+
+// Parse is like the package level Parse, but uses the parser options.
+func (p *Parser) Parse(fmt, value string) (time.Time, error) {
+	t, _, err := parse(fmt, value, p, false)
+	return t, err
+}
+
+// ParsePrefix is like the package level ParsePrefix, but uses the parser options.
+func (p *Parser) ParsePrefix(fmt, s string) (t time.Time, n int, err error) {
+	return parse(fmt, s, p, true)
+}
+
+func (p *Parser) locale() *Locale {
+	if p.Locale == nil {
+		return &defaultLocale
+	}
+	return p.Locale
+}
+
+// FixedWindow expands two-digit years to the 100 years starting at start
+// (e.g. FixedWindow(1950) expands to 1950..2049).
+func FixedWindow(start int) func(yy, ref int) int {
+	return func(yy, _ int) int {
+		return window(start, yy)
+	}
+}
+
+// SlidingWindow expands two-digit years to the 100 years
+// starting back years before the reference year
+// (e.g. SlidingWindow(80) expands to 80 years before, and 19 after).
+func SlidingWindow(back int) func(yy, ref int) int {
+	return func(yy, ref int) int {
+		return window(ref-back, yy)
+	}
+}
+
+// window returns the year ending in yy, in the 100 years starting at start.
+func window(start, yy int) int {
+	year := start - floorMod(start, 100) + yy
+	if year < start {
+		year += 100
+	}
+	return year
+}
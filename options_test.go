@@ -0,0 +1,214 @@
+package strftime_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestParser_Reference(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	newYear := time.Date(2025, 1, 1, 0, 10, 0, 0, cet)
+	wednesday := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format, value string
+		ref           time.Time
+		closest       bool
+		want          time.Time
+	}{
+		{"%b %e %T", "Mar  3 10:00:01", newYear, false, time.Date(2025, 3, 3, 10, 0, 1, 0, cet)},
+		{"%b %e %T", "Dec 31 23:59:00", newYear, false, time.Date(2025, 12, 31, 23, 59, 0, 0, cet)},
+		{"%b %e %T", "Dec 31 23:59:00", newYear, true, time.Date(2024, 12, 31, 23, 59, 0, 0, cet)},
+		{"%b %e %T", "Jan  1 00:00:00", newYear, true, time.Date(2025, 1, 1, 0, 0, 0, 0, cet)},
+		{"%b %e", "Feb 29", newYear, true, time.Date(2024, 2, 29, 0, 0, 0, 0, cet)},
+		{"%H:%M", "23:30", newYear, false, time.Date(2025, 1, 1, 23, 30, 0, 0, cet)},
+		{"%H:%M", "23:30", newYear, true, time.Date(2024, 12, 31, 23, 30, 0, 0, cet)},
+		{"%H:%M %z", "23:30 +0000", newYear, true, time.Date(2024, 12, 31, 23, 30, 0, 0, time.UTC)},
+		{"%a %H:%M", "Sun 10:00", wednesday, false, time.Date(2024, 3, 10, 10, 0, 0, 0, time.UTC)},
+		{"%a %H:%M", "Sun 10:00", wednesday, true, time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)},
+		{"%d", "29", wednesday, false, time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC)},
+		{"%d", "29", wednesday, true, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"%Y %d", "2023 29", wednesday, true, time.Date(2023, 3, 29, 0, 0, 0, 0, time.UTC)},
+		{"%F", "2023-03-29", newYear, true, time.Date(2023, 3, 29, 0, 0, 0, 0, cet)},
+		{"%s", "0", newYear, true, time.Unix(0, 0)},
+	}
+
+	for _, test := range tests {
+		p := strftime.Parser{Reference: test.ref, Closest: test.closest}
+		got, err := p.Parse(test.format, test.value)
+		if err != nil {
+			t.Errorf("Parse(%q, %q) error = %v", test.format, test.value, err)
+		} else if !got.Equal(test.want) || got.Format("MST") != test.want.Format("MST") {
+			t.Errorf("Parse(%q, %q) = %v, want %v", test.format, test.value, got, test.want)
+		}
+	}
+
+	p := strftime.Parser{Reference: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+	if got, err := p.Parse("%b %e", "Feb 29"); err == nil {
+		t.Errorf("Parse() = %v", got)
+	}
+
+	p.Closest = true
+	tm, err := p.ParseFields("%b %e", "Dec 31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Has(strftime.YearField) {
+		t.Error("Has(YearField) = true")
+	}
+	if got, err := tm.Time(); err != nil || got.Year() != 2024 {
+		t.Errorf("Time() = (%v, %v)", got, err)
+	}
+}
+
+func TestParser_TwoDigitYears(t *testing.T) {
+	ref := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format, value string
+		years         func(yy, ref int) int
+		want          int
+	}{
+		{"%y", "69", nil, 1969},
+		{"%y", "68", nil, 2068},
+		{"%y", "50", strftime.FixedWindow(1950), 1950},
+		{"%y", "49", strftime.FixedWindow(1950), 2049},
+		{"%y", "43", strftime.SlidingWindow(80), 2043},
+		{"%y", "44", strftime.SlidingWindow(80), 1944},
+		{"%y", "24", strftime.SlidingWindow(80), 2024},
+		{"%y", "99", strftime.SlidingWindow(0), 2099},
+		{"%C%y", "1949", strftime.FixedWindow(1950), 1949},
+		{"%g-W%V", "49-W10", strftime.FixedWindow(1950), 2049},
+	}
+
+	for _, test := range tests {
+		p := strftime.Parser{Reference: ref, TwoDigitYears: test.years}
+		got, err := p.Parse(test.format, test.value)
+		if err != nil {
+			t.Errorf("Parse(%q, %q) error = %v", test.format, test.value, err)
+		} else if got.Year() != test.want {
+			t.Errorf("Parse(%q, %q) = %v, want year %d", test.format, test.value, got, test.want)
+		}
+	}
+
+	p := strftime.Parser{TwoDigitYears: strftime.SlidingWindow(50)}
+	now := time.Now()
+	if got, err := p.Parse("%y", strftime.Format("%y", now)); err != nil || got.Year() != now.Year() {
+		t.Errorf("Parse() = (%v, %v), want year %d", got, err, now.Year())
+	}
+}
+
+func TestParser_Validate(t *testing.T) {
+	tests := []struct {
+		format, value string
+		want          string
+	}{
+		{"%a %d %b %Y", "Sun 03 Mar 2024", ""},
+		{"%a %d %b %Y", "Mon 03 Mar 2024", "weekday does not match date"},
+		{"%Y-%j %F", "2024-063 2024-03-03", ""},
+		{"%Y-%j %F", "2024-064 2024-03-03", "day-of-year does not match month/day"},
+		{"%G-W%V-%u %F", "2024-W09-7 2024-03-03", ""},
+		{"%G-W%V-%u %F", "2024-W10-7 2024-03-03", "week does not match date"},
+		{"%G-W%V-%u %F", "2024-W09-1 2024-03-03", "weekday does not match date"},
+		{"%G-W%V %F", "2025-W01 2024-12-30", ""},
+		{"%G-W%V %F", "2024-W01 2024-12-30", "week-based year does not match date"},
+		{"%F %U %W", "2024-03-03 09 09", ""},
+		{"%F %U", "2024-03-03 10", "week does not match date"},
+		{"%H:%M %p", "13:00 PM", ""},
+		{"%H:%M %p", "13:00 AM", "hour does not match AM/PM"},
+		{"%H:%M %p", "09:00 PM", "hour does not match AM/PM"},
+		{"%I:%M %p", "09:00 PM", ""},
+		{"%s %F %T", "1709460001 2024-03-03 10:00:01", ""},
+		{"%s %F", "1709460001 2024-03-04", "date does not match epoch"},
+		{"%s %T", "1709460001 10:00:02", "time does not match epoch"},
+		{"%C %Y", "20 2024", ""},
+		{"%C %Y", "19 2024", "century does not match year"},
+	}
+
+	p := strftime.Parser{Validate: true}
+	for _, test := range tests {
+		_, err := p.Parse(test.format, test.value)
+		switch {
+		case test.want == "" && err != nil:
+			t.Errorf("Parse(%q, %q) error = %v", test.format, test.value, err)
+		case test.want != "" && (err == nil || !strings.Contains(err.Error(), test.want)):
+			t.Errorf("Parse(%q, %q) error = %v, want %q", test.format, test.value, err, test.want)
+		}
+		if _, err := strftime.Parse(test.format, test.value); err != nil && !strings.Contains(test.want, "month/day") {
+			t.Errorf("Parse(%q, %q) error = %v", test.format, test.value, err)
+		}
+	}
+}
+
+func TestParser_LeapSecond(t *testing.T) {
+	tests := []struct {
+		policy strftime.LeapSecond
+		want   time.Time
+	}{
+		{strftime.ClampLeapSecond, time.Date(2016, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{strftime.RollLeapSecond, time.Date(2017, 1, 1, 0, 0, 0, 500000000, time.UTC)},
+	}
+
+	for _, test := range tests {
+		p := strftime.Parser{LeapSecond: test.policy}
+		got, err := p.Parse("%F %T.%L", "2016-12-31 23:59:60.500")
+		if err != nil {
+			t.Errorf("Parse() error = %v", err)
+		} else if !got.Equal(test.want) {
+			t.Errorf("Parse() = %v, want %v", got, test.want)
+		}
+		if got, err := p.Parse("%T", "23:59:61"); err == nil {
+			t.Errorf("Parse() = %v", got)
+		}
+	}
+
+	if got, err := strftime.Parse("%T", "23:59:60"); err == nil {
+		t.Errorf("Parse() = %v", got)
+	}
+}
+
+func TestParser_Mode(t *testing.T) {
+	tests := []struct {
+		mode          strftime.Mode
+		format, value string
+		want          time.Time
+	}{
+		{strftime.DefaultMode, "%F %T", "2024-3-3 10:0:1", time.Date(2024, 3, 3, 10, 0, 1, 0, time.UTC)},
+		{strftime.DefaultMode, "%d %b", "03 Mar.", time.Date(0, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.StrictMode, "%F %T", "2024-03-03 10:00:01", time.Date(2024, 3, 3, 10, 0, 1, 0, time.UTC)},
+		{strftime.StrictMode, "%F %T", "2024-3-03 10:00:01", time.Time{}},
+		{strftime.StrictMode, "%F %T", "2024-03-03 10:00:1", time.Time{}},
+		{strftime.StrictMode, "%F %T", "2024-03-03 10:00:010", time.Time{}},
+		{strftime.StrictMode, "%F %T", "2024-03-03 10:00:01.5", time.Time{}},
+		{strftime.StrictMode, "%F %T", "2024-03-03  10:00:01", time.Time{}},
+		{strftime.StrictMode, "%b %e %Y", "Mar  3 2024", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.StrictMode, "%b %e %Y", "Mar 03 2024", time.Time{}},
+		{strftime.StrictMode, "%b %e %Y", "Mar 3 2024", time.Time{}},
+		{strftime.StrictMode, "%d %b", "03 Mar.", time.Time{}},
+		{strftime.StrictMode, "%-m/%-d/%Y", "3/3/2024", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.StrictMode, "%-m/%-d/%Y", "03/3/2024", time.Time{}},
+		{strftime.StrictMode, "%k:%M", " 9:05", time.Date(0, 1, 1, 9, 5, 0, 0, time.UTC)},
+		{strftime.LenientMode, "%Y-%m-%d %H:%M", "  2024-3-3\t\t 9:5", time.Date(2024, 3, 3, 9, 5, 0, 0, time.UTC)},
+		{strftime.LenientMode, "%Y-%m-%d %H:%M", "2024-03-0309:05", time.Date(2024, 3, 3, 9, 5, 0, 0, time.UTC)},
+		{strftime.LenientMode, "%d/%m/%y", "3/ 3/ 7", time.Date(2007, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.LenientMode, "%Y %j", "24 64", time.Date(24, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{strftime.LenientMode, "%Y%m%d", "20240303", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{strftime.LenientMode, "%d-%m", "3 - 3", time.Time{}},
+	}
+
+	for _, test := range tests {
+		p := strftime.Parser{Mode: test.mode}
+		got, err := p.Parse(test.format, test.value)
+		switch {
+		case test.want.IsZero() && err == nil:
+			t.Errorf("Parse(%q, %q) = %v, want error", test.format, test.value, got)
+		case !test.want.IsZero() && err != nil:
+			t.Errorf("Parse(%q, %q) error = %v", test.format, test.value, err)
+		case !got.Equal(test.want):
+			t.Errorf("Parse(%q, %q) = %v, want %v", test.format, test.value, got, test.want)
+		}
+	}
+}
@@ -3,8 +3,16 @@ package strftime
 import "unicode/utf8"
 
 type parser struct {
-	format  func(spec, flag byte) error
-	literal func(byte) error
+	format   func(spec, flag byte) error
+	literal  func(byte) error
+	width    int  // width of the current directive, if any
+	modifier byte // modifier of the current directive, if any
+
+	// section, if set, gets the section directives (%[ %| %]);
+	// otherwise, only the first alternative of each section is kept.
+	section func(byte) error
+	depth   int // depth of the current section
+	skip    int // depth of the section being skipped, if any
 }
 
 func (p *parser) parse(fmt string) error {
@@ -12,10 +20,11 @@ func (p *parser) parse(fmt string) error {
 		initial = iota
 		percent
 		flagged
+		widened
 		modified
 	)
 
-	var flag, modifier byte
+	var flag byte
 	var err error
 	state := initial
 	start := 0
@@ -25,9 +34,11 @@ func (p *parser) parse(fmt string) error {
 			if b == '%' {
 				state = percent
 				start = i
+				p.width = 0
+				p.modifier = 0
 				continue
 			}
-			err = p.literal(b)
+			err = p.emitLiteral(b)
 
 		case percent:
 			if b == '-' || b == ':' {
@@ -37,25 +48,48 @@ func (p *parser) parse(fmt string) error {
 			}
 			if b == 'E' || b == 'O' {
 				state = modified
-				modifier = b
+				p.modifier = b
 				flag = 0
 				continue
 			}
-			err = p.format(b, 0)
+			if b == '[' || b == '|' || b == ']' {
+				err = p.sectionDirective(fmt[start:i+1], fmt[i+1:], b)
+			} else {
+				err = p.emitFormat(b, 0)
+			}
 			state = initial
 
 		case flagged:
 			if b == 'E' || b == 'O' {
 				state = modified
-				modifier = b
+				p.modifier = b
+				continue
+			}
+			if flag == ':' && '0' <= b && b <= '9' {
+				state = widened
+				p.width = int(b - '0')
 				continue
 			}
-			err = p.format(b, flag)
+			err = p.emitFormat(b, flag)
+			state = initial
+
+		case widened:
+			if '0' <= b && b <= '9' {
+				if p.width < 100 {
+					p.width = p.width*10 + int(b-'0')
+				}
+				continue
+			}
+			if okWidth(flag, b, p.width) {
+				err = p.emitFormat(b, flag)
+			} else {
+				err = p.literals(fmt[start : i+1])
+			}
 			state = initial
 
 		case modified:
-			if okModifier(modifier, b) {
-				err = p.format(b, flag)
+			if okModifier(p.modifier, b) {
+				err = p.emitFormat(b, flag)
 			} else {
 				err = p.literals(fmt[start : i+1])
 			}
@@ -79,13 +113,94 @@ func (p *parser) parse(fmt string) error {
 
 func (p *parser) literals(literal string) error {
 	for _, b := range []byte(literal) {
-		if err := p.literal(b); err != nil {
+		if err := p.emitLiteral(b); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func (p *parser) emitLiteral(b byte) error {
+	if p.skip > 0 {
+		return nil
+	}
+	return p.literal(b)
+}
+
+func (p *parser) emitFormat(spec, flag byte) error {
+	if p.skip > 0 {
+		return nil
+	}
+	return p.format(spec, flag)
+}
+
+// sectionDirective handles the directives of optional sections:
+// %[ starts a section, %| separates its alternatives, and %] ends it.
+// Outside sections, %| and %] are literals,
+// as is a %[ that the rest of the format doesn't close.
+func (p *parser) sectionDirective(directive, rest string, b byte) error {
+	if b != '[' && p.depth == 0 || b == '[' && !closed(rest) {
+		return p.literals(directive)
+	}
+	switch b {
+	case '[':
+		p.depth++
+	case '|':
+		if p.skip == 0 && p.section == nil {
+			p.skip = p.depth
+		}
+	case ']':
+		if p.skip == p.depth {
+			p.skip = 0
+		}
+		p.depth--
+	}
+	if p.skip > 0 || p.section == nil {
+		return nil
+	}
+	return p.section(b)
+}
+
+// closed reports if fmt closes the section it follows with a %].
+// Directives are skipped as parse reads them.
+func closed(fmt string) bool {
+	depth := 0
+	for i := 0; i < len(fmt); i++ {
+		if fmt[i] != '%' {
+			continue
+		}
+		if i++; i == len(fmt) {
+			break
+		}
+		switch fmt[i] {
+		case '[':
+			depth++
+			continue
+		case ']':
+			if depth == 0 {
+				return true
+			}
+			depth--
+			continue
+		}
+
+		// Skip the flag, width, and modifier; the loop skips the specifier.
+		if flag := fmt[i]; flag == '-' || flag == ':' {
+			i++
+			if flag == ':' && isDigit(fmt, i) {
+				for isDigit(fmt, i) {
+					i++
+				}
+				continue
+			}
+		}
+		if i < len(fmt) && (fmt[i] == 'E' || fmt[i] == 'O') {
+			i++
+		}
+	}
+	return false
+}
+
 type literalErr string
 
 func (e literalErr) Error() string {
@@ -6,14 +6,26 @@ The following formatting specifiers are available:
 	Date (Year, Month, Day):
 	  %Y - Year with century (can be negative, 4 digits at least)
 	          -0001, 0000, 1995, 2009, 14292, etc.
-	  %C - year / 100 (round down, 20 in 2009)
-	  %y - year % 100 (00..99)
+	          %:Y   ISO 8601 expanded year, with a mandatory sign (+2009)
+	          %:6Y  with at least 6 digits (4..18) (+002009)
+	  %C - year / 100 (round down, 20 in 2009, -1 in -0001)
+	  %y - year % 100 (00..99, 99 in -0001)
+
+	  %:C - Era (BC or AD, names can be localized)
+	  %:y - Year of the era (no year zero, 44 in 44 BC, 2009 in AD 2009)
+
+	  %EC - Name of the alternative era of the locale (e.g. 令和)
+	  %Ey - Year of the alternative era
+	  %EY - Alternative year representation of the locale (e.g. 令和6年, 令和元年)
+	          without alternative eras, or before the first, these are %C %y and %Y
 
 	  %m - Month of the year, zero-padded (01..12)
 	          %-m  no-padded (1..12)
 	  %B - Full month name (January)
 	  %b - Abbreviated month name (Jan)
 	  %h - Equivalent to %b
+	          %OB %Ob %Oh  standalone (nominative) month names,
+	                       in languages that distinguish them (январь)
 
 	  %d - Day of the month, zero-padded  (01..31)
 	          %-d  no-padded (1..31)
@@ -62,6 +74,25 @@ The following formatting specifiers are available:
 	  %V - Week number of the week-based year (01..53)
 	          %-V  no-padded (1..53)
 
+	Locale week-based year and week number:
+	Week 1 of YYYY starts with the first day of the week of the locale,
+	and includes at least the minimal days of the locale in YYYY.
+	The days in the year before the first week are in the last week of
+	the previous year.
+	The default locale uses ISO 8601 rules.
+	  %:G - Week-based year
+	  %:g - Last 2 digits of the week-based year (00..99)
+	  %:V - Week number of the week-based year (01..53)
+	  %:u - Day of the week (the first day of the week is 1, 1..7)
+
+	Week of the month:
+	Week 1 of the month starts with the first day of the week of the locale,
+	and includes at least the minimal days of the locale in the month.
+	The days in the month before the first week are in week 0.
+	  %:W - Week of the month (0..6)
+	  %:w - Occurrence of the day of the week in the month (1..5)
+	          the 2nd Tuesday of the month is %:w = 2
+
 	Week number:
 	Week 1 of YYYY starts with a Sunday or Monday (according to %U or %W).
 	The days in the year before the first week are in week 0.
@@ -70,15 +101,47 @@ The following formatting specifiers are available:
 	  %W - Week number of the year.  The week starts with Monday.  (00..53)
 	          %-W  no-padded (0..53)
 
+	Day counts:
+	  %J  - Julian Day Number (2451545 on 2000-01-01)
+	          %:J  Julian Date, with a fraction of the day
+	               (2451545.0 on 2000-01-01 at 12:00)
+	  %K  - Modified Julian Day (51544 on 2000-01-01)
+	          %:K  Modified Julian Date, with a fraction of the day
+	               (51544.5 on 2000-01-01 at 12:00)
+	  %i  - Spreadsheet (OLE Automation) serial day (36526 on 2000-01-01)
+	          %:i  serial date, with a fraction of the day
+	               (36526.5 on 2000-01-01 at 12:00);
+	               before 1899-12-30 the fraction is still added
+	               (-1.25 on 1899-12-29 at 06:00)
+	Julian and Modified Julian Days count days of Universal Time,
+	and are parsed as UTC; spreadsheet serials count days of the
+	wall clock of the time being formatted.
+
 	Seconds since the Unix Epoch:
 	  %s - Number of seconds since 1970-01-01 00:00:00 UTC.
 	  %Q - Number of milliseconds since 1970-01-01 00:00:00 UTC.
 
+	Ordinal suffix:
+	  %o - Ordinal suffix of the preceding number,
+	       or of the day of the month (st, nd, rd, th)
+	          %B %-d%o  March 3rd
+
 	Literal string:
 	  %n - Newline character (\n)
 	  %t - Tab character (\t)
 	  %% - Literal % character
 
+	Optional sections:
+	  %[ - Start of an optional section
+	  %| - Separator of alternatives in a section
+	  %] - End of an optional section
+	          %FT%R%[:%S%[.%N%]%]%[Z%|%:z%]
+	Parsing uses the alternative that matches the most, if any,
+	and doesn't backtrack into sections.
+	Formatting uses the first alternative.
+	A %[ that isn't closed by a %] is a literal,
+	as are %| and %] outside sections.
+
 	Combination:
 	  %c - date and time (%a %b %e %T %Y)
 	  %D - Date (%m/%d/%y)
@@ -91,6 +154,20 @@ The following formatting specifiers are available:
 	  %T - 24-hour time (%H:%M:%S)
 	  %+ - date(1) (%a %b %e %H:%M:%S %Z %Y)
 
-The modifiers “E” and “O” are ignored.
+Calendars:
+Locales can use calendar systems other than the proleptic Gregorian
+calendar (Buddhist, SolarHijri, IslamicCivil, Hebrew, or a custom Calendar).
+The calendar applies to %Y %C %y %:y %m %B %b %d %e %j %U %W %:w %:W,
+and the combinations that use them.
+Week-based years, their week numbers (%G %g %V %:G %:V), and day counts
+always use the Gregorian calendar.
+
+Alternative digits:
+The modifier “O” uses the alternative digits of the locale, if any,
+for numeric directives (e.g. %Od, %OH, %OY).
+Locales can also use alternative digits for all numbers.
+Parsing accepts both ASCII and alternative digits.
+
+Other uses of the modifier “E” are ignored.
 */
 package strftime
@@ -0,0 +1,103 @@
+package strftime
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// English is an English locale, with US week rules.
+var English = &Locale{
+	FirstDay: time.Sunday,
+	MinDays:  1,
+}
+
+// posixLocale is the C locale: a copy of the default locale,
+// so that changing it doesn't change the package level functions.
+var posixLocale = defaultLocale
+
+var registry = struct {
+	sync.RWMutex
+	locales map[string]*Locale
+}{
+	locales: map[string]*Locale{
+		"C":     &posixLocale,
+		"POSIX": &posixLocale,
+		"el":    Greek,
+		"en":    English,
+		"fr":    French,
+		"ja":    Japanese,
+		"pl":    Polish,
+		"ru":    Russian,
+		"uk":    Ukrainian,
+	},
+}
+
+// RegisterLocale registers a locale with a name,
+// in the ll_CC@modifier form (e.g. "pt", "pt_BR", or "sr_RS@latin").
+// It is meant to be called from init functions,
+// and replaces any locale previously registered with the name.
+func RegisterLocale(name string, l *Locale) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.locales[name] = l
+}
+
+// LookupLocale returns the locale registered with a name,
+// in the ll_CC.codeset@modifier form (e.g. "pt_BR.UTF-8").
+// The codeset is ignored, and the territory and the modifier are dropped,
+// in that order, until a registered locale is found
+// (e.g. "pt_BR@euro", "pt@euro", "pt_BR", "pt").
+func LookupLocale(name string) (*Locale, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	for _, name := range localeNames(name) {
+		if l, ok := registry.locales[name]; ok {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+// EnvLocale returns the time locale of the environment,
+// given by the first non-empty variable among LC_ALL, LC_TIME and LANG.
+// If none of them names a registered locale, the C locale is returned,
+// which has the same rules as the package level functions.
+func EnvLocale() *Locale {
+	for _, env := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if name := os.Getenv(env); name != "" {
+			if l, ok := LookupLocale(name); ok {
+				return l
+			}
+			break
+		}
+	}
+	return &posixLocale
+}
+
+// localeNames returns the names to lookup for a locale name, in order.
+func localeNames(name string) []string {
+	var modifier, territory string
+	if i := strings.IndexByte(name, '@'); i >= 0 {
+		name, modifier = name[:i], name[i:]
+	}
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.IndexAny(name, "_-"); i >= 0 {
+		name, territory = name[:i], "_"+name[i+1:]
+	}
+
+	var names []string
+	if modifier != "" {
+		if territory != "" {
+			names = append(names, name+territory+modifier)
+		}
+		names = append(names, name+modifier)
+	}
+	if territory != "" {
+		names = append(names, name+territory)
+	}
+	return append(names, name)
+}
@@ -0,0 +1,78 @@
+package strftime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestLookupLocale(t *testing.T) {
+	brazil := &strftime.Locale{FirstDay: time.Sunday, MinDays: 1}
+	strftime.RegisterLocale("pt_BR", brazil)
+
+	tests := []struct {
+		name string
+		want *strftime.Locale
+	}{
+		{"ru", strftime.Russian},
+		{"ru_RU", strftime.Russian},
+		{"ru_RU.UTF-8", strftime.Russian},
+		{"ru_UA.KOI8-U@dict", strftime.Russian},
+		{"uk-UA", strftime.Ukrainian},
+		{"ja_JP.eucJP", strftime.Japanese},
+		{"pt_BR.UTF-8", brazil},
+		{"pt_BR@euro", brazil},
+		{"en_US.UTF-8", strftime.English},
+	}
+
+	for _, test := range tests {
+		if got, ok := strftime.LookupLocale(test.name); !ok || got != test.want {
+			t.Errorf("LookupLocale(%q) = (%p, %v), want %p", test.name, got, ok, test.want)
+		}
+	}
+
+	for _, name := range []string{"", "pt", "pt_PT", "xx_RU"} {
+		if got, ok := strftime.LookupLocale(name); ok {
+			t.Errorf("LookupLocale(%q) = %p", name, got)
+		}
+	}
+}
+
+func TestEnvLocale(t *testing.T) {
+	c, _ := strftime.LookupLocale("C")
+	date := time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		all, time, lang string
+		want            *strftime.Locale
+	}{
+		{"", "", "", c},
+		{"", "", "ru_RU.UTF-8", strftime.Russian},
+		{"", "pl_PL.UTF-8", "ru_RU.UTF-8", strftime.Polish},
+		{"el_GR.UTF-8", "pl_PL.UTF-8", "ru_RU.UTF-8", strftime.Greek},
+		{"C", "pl_PL.UTF-8", "ru_RU.UTF-8", c},
+		{"", "xx_XX.UTF-8", "ru_RU.UTF-8", c},
+	}
+
+	for _, test := range tests {
+		t.Setenv("LC_ALL", test.all)
+		t.Setenv("LC_TIME", test.time)
+		t.Setenv("LANG", test.lang)
+		if got := strftime.EnvLocale(); got != test.want {
+			t.Errorf("EnvLocale(%q, %q, %q) = %p, want %p", test.all, test.time, test.lang, got, test.want)
+		}
+	}
+
+	if got := c.Format("%c", date); got != strftime.Format("%c", date) {
+		t.Errorf("Format() = %q", got)
+	}
+
+	// Changing the C locale doesn't change the package level functions.
+	saved := *c
+	defer func() { *c = saved }()
+	c.Months[4] = "Mai"
+	if got := strftime.Format("%B", date); got != "May" {
+		t.Errorf("Format() = %q", got)
+	}
+}
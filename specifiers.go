@@ -59,6 +59,9 @@ func goLayout(spec, flag byte, parsing bool) string {
 	case 'y':
 		return "06"
 	case 'Y':
+		if flag == ':' {
+			return ""
+		}
 		return "2006"
 	case 'p':
 		return "PM"
@@ -125,15 +128,53 @@ func goLayout(spec, flag byte, parsing bool) string {
 	}
 }
 
+// combination returns the strftime format equivalent to a combination specifier.
+func combination(spec byte) string {
+	switch spec {
+	default:
+		return ""
+	case '+':
+		return "%a %b %e %H:%M:%S %Z %Y"
+	case 'c':
+		return "%a %b %e %H:%M:%S %Y"
+	case 'v':
+		return "%e-%b-%Y"
+	case 'F':
+		return "%Y-%m-%d"
+	case 'D', 'x':
+		return "%m/%d/%y"
+	case 'r':
+		return "%I:%M:%S %p"
+	case 'T', 'X':
+		return "%H:%M:%S"
+	case 'R':
+		return "%H:%M"
+	}
+}
+
+// okParse reports whether spec can be parsed.
+func okParse(spec, flag byte) bool {
+	if flag == ':' {
+		return strings.Contains("CgGiJKuVwWyYz", string(spec))
+	}
+	return strings.Contains("aAbBCdefgGhHiIjJkKlLmMNopPQsSuUVwWyYzZ", string(spec))
+}
+
 // https://nsdateformatter.com/
-func uts35Pattern(spec, flag byte) string {
+func uts35Pattern(spec, flag, mod byte) string {
 	switch spec {
 	default:
 		return ""
 
 	case 'B':
+		if mod == 'O' {
+			return "LLLL"
+		}
 		return "MMMM"
 	case 'b', 'h':
+		if mod == 'O' {
+			return "LLL"
+		}
 		return "MMM"
 	case 'm':
 		if flag == '-' {
@@ -175,8 +216,19 @@ func uts35Pattern(spec, flag byte) string {
 		}
 		return "ss"
 	case 'y':
+		if flag == ':' {
+			return "y"
+		}
 		return "yy"
+	case 'C':
+		if flag == ':' {
+			return "G"
+		}
+		return ""
 	case 'Y':
+		if flag == ':' {
+			return ""
+		}
 		return "yyyy"
 	case 'g':
 		return "YY"
@@ -187,6 +239,26 @@ func uts35Pattern(spec, flag byte) string {
 			return "w"
 		}
 		return "ww"
+	case 'u':
+		if flag == ':' {
+			return "e"
+		}
+		return ""
+	case 'W':
+		if flag == ':' {
+			return "W"
+		}
+		return ""
+	case 'w':
+		if flag == ':' {
+			return "F"
+		}
+		return ""
+	case 'J':
+		if flag == 0 {
+			return "g"
+		}
+		return ""
 	case 'p':
 		return "a"
 	case 'Z':
@@ -229,13 +301,26 @@ func uts35Pattern(spec, flag byte) string {
 	}
 }
 
+// okWidth reports whether the directive accepts a width.
+func okWidth(flag, spec byte, width int) bool {
+	return flag == ':' && spec == 'Y' && 4 <= width && width <= 18
+}
+
+// expandedYear returns the number of digits of an expanded year.
+func expandedYear(width int) int {
+	if width == 0 {
+		return 4
+	}
+	return width
+}
+
 // http://man.he.net/man3/strftime
 func okModifier(mod, spec byte) bool {
 	if mod == 'E' {
 		return strings.Contains("cCxXyY", string(spec))
 	}
 	if mod == 'O' {
-		return strings.Contains("deHImMSuUVwWy", string(spec))
+		return strings.Contains("bBCdegGhHIjklmMSuUVwWyY", string(spec))
 	}
 	return false
 }
@@ -3,6 +3,7 @@ package strftime
 import (
 	"bytes"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +17,11 @@ func Format(fmt string, t time.Time) string {
 // AppendFormat is like Format, but appends the textual representation
 // to dst and returns the extended buffer.
 func AppendFormat(dst []byte, fmt string, t time.Time) []byte {
+	return defaultLocale.AppendFormat(dst, fmt, t)
+}
+
+// appendFormat formats t, which has the fields in has.
+func appendFormat(dst []byte, fmt string, t time.Time, l *Locale, has provided) ([]byte, error) {
 	var parser parser
 
 	parser.literal = func(b byte) error {
@@ -23,28 +29,44 @@ func AppendFormat(dst []byte, fmt string, t time.Time) []byte {
 		return nil
 	}
 
-	parser.format = func(spec, flag byte) error {
+	// In a leap second, format the previous second as second 60.
+	var leap bool
+	unix := t
+	if len(l.LeapSeconds) > 0 && l.inLeapSecond(t) {
+		t, leap = t.Add(-time.Second), true
+	}
+
+	year, month, day := l.date(t)
+	var inEraYear bool
+
+	format := func(spec, flag byte) error {
 		switch spec {
 		case 'A':
-			dst = append(dst, t.Weekday().String()...)
+			dst = append(dst, l.days(false)[t.Weekday()]...)
 			return nil
 		case 'a':
-			dst = append(dst, t.Weekday().String()[:3]...)
+			dst = append(dst, l.days(true)[t.Weekday()]...)
 			return nil
 		case 'B':
-			dst = append(dst, t.Month().String()...)
+			dst = append(dst, l.monthName(year, month, false, parser.modifier == 'O')...)
 			return nil
 		case 'b', 'h':
-			dst = append(dst, t.Month().String()[:3]...)
+			dst = append(dst, l.monthName(year, month, true, parser.modifier == 'O')...)
 			return nil
 		case 'm':
-			dst = appendInt2(dst, int(t.Month()), flag)
+			dst = appendInt2(dst, month, flag)
 			return nil
 		case 'd':
-			dst = appendInt2(dst, int(t.Day()), flag)
+			dst = appendInt2(dst, day, flag)
 			return nil
 		case 'e':
-			dst = appendInt2(dst, int(t.Day()), ' ')
+			dst = appendInt2(dst, day, ' ')
+			return nil
+		case 'p':
+			dst = append(dst, l.dayPeriods()[t.Hour()/12]...)
+			return nil
+		case 'P':
+			dst = append(dst, strings.ToLower(l.dayPeriods()[t.Hour()/12])...)
 			return nil
 		case 'I':
 			dst = append12Hour(dst, t, flag)
@@ -62,7 +84,11 @@ func AppendFormat(dst []byte, fmt string, t time.Time) []byte {
 			dst = appendInt2(dst, t.Minute(), flag)
 			return nil
 		case 'S':
-			dst = appendInt2(dst, t.Second(), flag)
+			if leap {
+				dst = appendInt2(dst, 60, flag)
+			} else {
+				dst = appendInt2(dst, t.Second(), flag)
+			}
 			return nil
 		case 'L':
 			dst = append(dst, t.Format(".000")[1:]...)
@@ -74,45 +100,112 @@ func AppendFormat(dst []byte, fmt string, t time.Time) []byte {
 			dst = append(dst, t.Format(".000000000")[1:]...)
 			return nil
 		case 'y':
-			dst = t.AppendFormat(dst, "06")
+			if parser.modifier == 'E' {
+				if _, y, ok := l.altEra(t); ok {
+					dst = strconv.AppendInt(dst, int64(y), 10)
+					return nil
+				}
+			}
+			if flag == ':' {
+				_, y := era(year)
+				dst = strconv.AppendInt(dst, int64(y), 10)
+			} else {
+				_, y := splitYear(year)
+				dst = appendInt2(dst, y, 0)
+			}
 			return nil
 		case 'Y':
-			dst = t.AppendFormat(dst, "2006")
+			if parser.modifier == 'E' && !inEraYear {
+				if _, y, ok := l.altEra(t); ok {
+					fmt := l.eraYear()
+					if y == 1 && l.FirstEraYear != "" {
+						fmt = strings.ReplaceAll(fmt, "%Ey", l.FirstEraYear)
+					}
+					inEraYear = true
+					err := parser.parse(fmt)
+					inEraYear = false
+					return err
+				}
+			}
+			if flag == ':' {
+				dst = appendSigned(dst, year, expandedYear(parser.width), true)
+			} else {
+				dst = appendSigned(dst, year, 4, false)
+			}
 			return nil
 		case 'C':
-			dst = t.AppendFormat(dst, "2006")
-			dst = dst[:len(dst)-2]
+			if parser.modifier == 'E' {
+				if e, _, ok := l.altEra(t); ok {
+					dst = append(dst, l.Eras[e].Name...)
+					return nil
+				}
+			}
+			if flag == ':' {
+				e, _ := era(year)
+				dst = append(dst, l.eraNames()[e]...)
+				return nil
+			}
+			c, _ := splitYear(year)
+			if flag == '-' {
+				dst = appendSigned(dst, c, 0, false)
+			} else {
+				dst = appendSigned(dst, c, 2, false)
+			}
 			return nil
 		case 'U':
-			dst = appendWeekNumber(dst, t, flag, true)
+			dst = appendInt2(dst, l.yearWeek(t, time.Sunday), flag)
 			return nil
 		case 'W':
-			dst = appendWeekNumber(dst, t, flag, false)
+			if flag == ':' {
+				dst = appendInt1(dst, l.monthWeek(day, t.Weekday()))
+			} else {
+				dst = appendInt2(dst, l.yearWeek(t, time.Monday), flag)
+			}
 			return nil
 		case 'V':
-			_, w := t.ISOWeek()
-			dst = appendInt2(dst, w, flag)
+			if flag == ':' {
+				_, w := l.week(t)
+				dst = appendInt2(dst, w, 0)
+			} else {
+				_, w := t.ISOWeek()
+				dst = appendInt2(dst, w, flag)
+			}
 			return nil
 		case 'g':
 			y, _ := t.ISOWeek()
-			dst = year(y).AppendFormat(dst, "06")
+			if flag == ':' {
+				y, _ = l.week(t)
+			}
+			_, y = splitYear(y)
+			dst = appendInt2(dst, y, 0)
 			return nil
 		case 'G':
 			y, _ := t.ISOWeek()
-			dst = year(y).AppendFormat(dst, "2006")
+			if flag == ':' {
+				y, _ = l.week(t)
+			}
+			dst = appendSigned(dst, y, 4, false)
+			return nil
+		case 'J', 'K', 'i':
+			dst = appendDays(dst, t, spec, flag == ':')
 			return nil
 		case 's':
-			dst = strconv.AppendInt(dst, t.Unix(), 10)
+			dst = strconv.AppendInt(dst, unix.Unix(), 10)
 			return nil
 		case 'Q':
-			dst = strconv.AppendInt(dst, t.UnixMilli(), 10)
+			dst = strconv.AppendInt(dst, unix.UnixMilli(), 10)
 			return nil
 		case 'w':
-			w := t.Weekday()
-			dst = appendInt1(dst, int(w))
+			if flag == ':' {
+				dst = appendInt1(dst, (day+6)/7)
+			} else {
+				dst = appendInt1(dst, int(t.Weekday()))
+			}
 			return nil
 		case 'u':
-			if w := t.Weekday(); w == 0 {
+			if flag == ':' {
+				dst = appendInt1(dst, l.weekday(t.Weekday()))
+			} else if w := t.Weekday(); w == 0 {
 				dst = append(dst, '7')
 			} else {
 				dst = appendInt1(dst, int(w))
@@ -120,13 +213,16 @@ func AppendFormat(dst []byte, fmt string, t time.Time) []byte {
 			return nil
 		case 'j':
 			if flag == '-' {
-				dst = strconv.AppendInt(dst, int64(t.YearDay()), 10)
+				dst = strconv.AppendInt(dst, int64(l.yearDay(t)), 10)
 			} else {
-				dst = t.AppendFormat(dst, "002")
+				dst = appendSigned(dst, l.yearDay(t), 3, false)
 			}
 			return nil
 		}
 
+		if fmt := combination(spec); fmt != "" {
+			return parser.parse(fmt)
+		}
 		if layout := goLayout(spec, flag, false); layout != "" {
 			dst = t.AppendFormat(dst, layout)
 			return nil
@@ -140,27 +236,76 @@ func AppendFormat(dst []byte, fmt string, t time.Time) []byte {
 		return nil
 	}
 
-	parser.parse(fmt)
-	return dst
+	// The ordinal suffix applies to the preceding number,
+	// or to the day of the month.
+	var number []byte
+	parser.format = func(spec, flag byte) error {
+		if has != hasAll {
+			need := l.needs(spec, flag, parser.modifier)
+			if spec == 'o' && number == nil {
+				need = l.needs('d', 0, 0)
+			}
+			if need&^has != 0 {
+				it := item{spec: spec, flag: flag, mod: parser.modifier, width: parser.width}
+				return fieldError{directive: it.String(), field: missingField(need &^ has)}
+			}
+		}
+		if spec == 'o' {
+			n, ok := atoi(string(number))
+			if !ok {
+				n = day
+			}
+			dst = append(dst, l.ordinal(n)...)
+			return nil
+		}
+		start := len(dst)
+		err := format(spec, flag)
+		end := len(dst)
+		num := end > start && '0' <= dst[end-1] && dst[end-1] <= '9'
+		if num {
+			number = dst[start:end:end]
+		}
+		if end > start && l.altDigits(parser.modifier, spec) {
+			s := string(dst[start:])
+			if num {
+				number = []byte(s)
+			}
+			dst = l.AltDigits.append(dst[:start], s)
+		}
+		return err
+	}
+
+	err := parser.parse(fmt)
+	return dst, err
 }
 
 // Parse converts a textual representation of time to the time value it represents
 // according to the strptime format specification.
 //
-// The following specifiers are not supported for parsing:
-//
-//	%g %k %l %s %u %w %C %G %Q %U %V %W
-//
-// You must also avoid digits and these letter sequences
-// in fmt literals:
+// All formatting specifiers are supported for parsing.
+// Names are matched ignoring case, and a trailing dot after abbreviations,
+// and common alternative spellings (Sept, Tues, a.m.) are accepted.
+// Missing date fields default to January 1 of year 0,
+// missing time fields to midnight, and missing zones to UTC.
+// Weekdays and week numbers are only used to determine dates
+// when no month, day, or day of the year is given.
 //
-//	Jan Mon MST PM pm
+// Years can be signed, and have more than 4 digits,
+// unless directly followed by another directive (as in %Y%m%d).
+// Other numbers can omit their padding (e.g. %m matches 3 or 03);
+// see Parser.Mode for stricter and more lenient parsing.
 func Parse(fmt, value string) (time.Time, error) {
-	pattern, err := layout(fmt, true)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return time.Parse(pattern, value)
+	return defaultLocale.Parse(fmt, value)
+}
+
+// ParsePrefix is like Parse, but parses a time at the start of s,
+// and returns the number of bytes it consumed.
+// Text after the time is ignored (e.g. the message of a log line).
+// A year, or a fraction of a second, at the end of the format
+// takes all the digits that follow it in s.
+// Errors report all of s as the value being parsed.
+func ParsePrefix(fmt, s string) (t time.Time, n int, err error) {
+	return defaultLocale.ParsePrefix(fmt, s)
 }
 
 // Layout converts a strftime format specification
@@ -170,6 +315,8 @@ func Parse(fmt, value string) (time.Time, error) {
 //
 //	%f %g %k %l %s %u %w %C %G %L %N %Q %U %V %W
 //
+// Nor are optional sections (%[ %| %]).
+//
 // You must also avoid digits and these letter sequences
 // in fmt literals:
 //
@@ -204,6 +351,11 @@ func layout(fmt string, parsing bool) (string, error) {
 		return nil
 	}
 
+	// Go patterns have no optional sections.
+	parser.section = func(byte) error {
+		return formatError{}
+	}
+
 	parser.format = func(spec, flag byte) error {
 		if layout := goLayout(spec, flag, parsing); layout != "" {
 			dst = append(dst, layout...)
@@ -241,7 +393,11 @@ func layout(fmt string, parsing bool) (string, error) {
 //
 // The following specifiers are not supported by UTS35:
 //
-//	%e %k %l %u %w %C %P %U %W
+//	%e %i %k %l %o %s %u %w %C %K %P %Q %U %W %:i %:J %:K %:Y
+//
+// These ':' forms are supported, although the plain forms are not:
+// %:u (e), %:w (F), %:C (G) and %:W (W).
+// Optional sections (%[ %| %]) are not supported.
 func UTS35(fmt string) (string, error) {
 	const quote = '\''
 	var quoted bool
@@ -262,12 +418,17 @@ func UTS35(fmt string) (string, error) {
 		return nil
 	}
 
+	// UTS35 patterns have no sections of alternatives.
+	parser.section = func(byte) error {
+		return formatError{}
+	}
+
 	parser.format = func(spec, flag byte) error {
 		if quoted {
 			dst = append(dst, quote)
 			quoted = false
 		}
-		if pattern := uts35Pattern(spec, flag); pattern != "" {
+		if pattern := uts35Pattern(spec, flag, parser.modifier); pattern != "" {
 			dst = append(dst, pattern...)
 			return nil
 		}
@@ -295,18 +456,14 @@ func buffer(format string) (buf []byte) {
 	return
 }
 
-func year(y int) time.Time {
-	return time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
-}
-
-func appendWeekNumber(dst []byte, t time.Time, flag byte, sunday bool) []byte {
-	offset := int(t.Weekday())
-	if sunday {
-		offset = 6 - offset
-	} else if offset != 0 {
-		offset = 7 - offset
+// splitYear splits a year into century and year of the century,
+// rounding the century down (-1 is the year 99 of century -1).
+func splitYear(year int) (century, yy int) {
+	century, yy = year/100, year%100
+	if yy < 0 {
+		century, yy = century-1, yy+100
 	}
-	return appendInt2(dst, (t.YearDay()+offset)/7, flag)
+	return century, yy
 }
 
 func append12Hour(dst []byte, t time.Time, flag byte) []byte {
@@ -319,6 +476,51 @@ func append12Hour(dst []byte, t time.Time, flag byte) []byte {
 	return appendInt2(dst, h, flag)
 }
 
+// atoi parses a blank-padded, optionally signed, decimal number.
+func atoi(s string) (n int, ok bool) {
+	i := 0
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	neg := i < len(s) && s[i] == '-'
+	if neg {
+		i++
+	}
+	if i == len(s) || len(s)-i > 18 {
+		return 0, false
+	}
+	for ; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+		n = n*10 + int(s[i]-'0')
+	}
+	if neg {
+		n = -n
+	}
+	return n, true
+}
+
+// appendSigned appends i zero-padded to at least width digits,
+// with a sign if negative, or if plus is set.
+func appendSigned(dst []byte, i, width int, plus bool) []byte {
+	u := uint64(i)
+	if i < 0 {
+		dst = append(dst, '-')
+		u = -u
+	} else if plus {
+		dst = append(dst, '+')
+	}
+	digits := 1
+	for n := u; n >= 10; n /= 10 {
+		digits++
+	}
+	for ; digits < width; digits++ {
+		dst = append(dst, '0')
+	}
+	return strconv.AppendUint(dst, u, 10)
+}
+
 func appendInt1(dst []byte, i int) []byte {
 	return append(dst, byte('0'+i))
 }
@@ -2,6 +2,8 @@ package strftime_test
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -71,6 +73,8 @@ var timeTests = []struct {
 	{"%", "%", "%", "%"},
 	{"%%", "%", "%", "%"},
 	{"%-", "%-", "%-", "%-"},
+	{"%[", "%[", "%[", "%["},
+	{"%H%[:%M%]", "", "", "06:05"},
 	{"%n", "\n", "\n", "\n"},
 	{"%t", "\t", "\t", "\t"},
 	{"%q", "", "", "%q"},
@@ -195,6 +199,201 @@ func TestFormat_WeekNumber(t *testing.T) {
 	}
 }
 
+func TestFormat_Year(t *testing.T) {
+	tests := []struct {
+		year int
+		want string
+	}{
+		{-12345, "-12345 -124 55 -12345 -012345"},
+		{-101, "-0101 -02 99 -0101 -000101"},
+		{-100, "-0100 -01 00 -0100 -000100"},
+		{-43, "-0043 -01 57 -0043 -000043"},
+		{-1, "-0001 -01 99 -0001 -000001"},
+		{0, "0000 00 00 +0000 +000000"},
+		{1, "0001 00 01 +0001 +000001"},
+		{99, "0099 00 99 +0099 +000099"},
+		{100, "0100 01 00 +0100 +000100"},
+		{2009, "2009 20 09 +2009 +002009"},
+		{9999, "9999 99 99 +9999 +009999"},
+		{10000, "10000 100 00 +10000 +010000"},
+		{14292, "14292 142 92 +14292 +014292"},
+		{1234567, "1234567 12345 67 +1234567 +1234567"},
+	}
+
+	for _, test := range tests {
+		base := time.Date(test.year, time.July, 1, 0, 0, 0, 0, time.UTC)
+		if got := strftime.Format("%Y %C %y %:Y %:6Y", base); got != test.want {
+			t.Errorf("Format(%d) = %q, want %q", test.year, got, test.want)
+		}
+		fields := strings.Fields(test.want)
+		if got, want := strftime.Format("%G %g", base), fields[0]+" "+fields[2]; got != want {
+			t.Errorf("Format(%d) = %q, want %q", test.year, got, want)
+		}
+
+		formats := []string{"%Y-%m-%d", "%C %y-%j", "%:Y-%m-%d", "%G-W%V-%u"}
+		if -10000 < test.year && test.year < 10000 {
+			formats = append(formats, "%Y%m%d", "%C%y%m%d", "%:6Y%m%d")
+		}
+		for _, format := range formats {
+			value := strftime.Format(format, base)
+			if got, err := strftime.Parse(format, value); err != nil {
+				t.Errorf("Parse(%q, %q) = %v", format, value, err)
+			} else if !got.Equal(base) {
+				t.Errorf("Parse(%q, %q) = %v, want %v", format, value, got, base)
+			}
+		}
+	}
+
+	for _, value := range []string{"2009", "-2009", "123", "+12345"} {
+		if got, err := strftime.Parse("%:6Y", value); err == nil {
+			t.Errorf("Parse(%q, %q) = %v", "%:6Y", value, got)
+		}
+	}
+	for width := 11; width <= 18; width++ {
+		base := time.Date(2009, time.July, 1, 0, 0, 0, 0, time.UTC)
+		year := "%:" + strconv.Itoa(width) + "Y"
+		for _, format := range []string{year + "-%m-%d", year + "%m%d"} {
+			value := strftime.Format(format, base)
+			if got, err := strftime.Parse(format, value); err != nil {
+				t.Errorf("Parse(%q, %q) = %v", format, value, err)
+			} else if !got.Equal(base) {
+				t.Errorf("Parse(%q, %q) = %v, want %v", format, value, got, base)
+			}
+		}
+	}
+	for _, format := range []string{"%:0Y", "%:3Y", "%:19Y", "%:6d"} {
+		if got := strftime.Format(format, reference); got != format {
+			t.Errorf("Format(%q) = %q", format, got)
+		}
+	}
+}
+
+func TestFormat_Era(t *testing.T) {
+	tests := []struct {
+		year int
+		want string
+	}{
+		{-43, "44 BC"},
+		{-1, "2 BC"},
+		{0, "1 BC"},
+		{1, "1 AD"},
+		{2009, "2009 AD"},
+	}
+
+	for _, test := range tests {
+		base := time.Date(test.year, time.March, 15, 0, 0, 0, 0, time.UTC)
+		if got := strftime.Format("%:y %:C", base); got != test.want {
+			t.Errorf("Format(%d) = %q, want %q", test.year, got, test.want)
+		}
+		if got, err := strftime.Parse("%B %-d, %:y %:C", strftime.Format("%B %-d, %:y %:C", base)); err != nil {
+			t.Error(err)
+		} else if !got.Equal(base) {
+			t.Errorf("Parse(%d) = %v, want %v", test.year, got, base)
+		}
+	}
+
+	if got, err := strftime.Parse("%:y", "0"); err == nil {
+		t.Errorf("Parse(%q) = %v", "0", got)
+	}
+	if got, err := strftime.Parse("%:y", "2009"); err != nil || got.Year() != 2009 {
+		t.Errorf("Parse(%q) = (%v, %v)", "2009", got, err)
+	}
+	if got, err := strftime.UTS35("%:y %:C"); err != nil || got != "y G" {
+		t.Errorf("UTS35(%q) = (%q, %v)", "%:y %:C", got, err)
+	}
+}
+
+func TestFormat_Ordinal(t *testing.T) {
+	suffixes := []string{"th",
+		"st", "nd", "rd", "th", "th", "th", "th", "th", "th", "th",
+		"th", "th", "th", "th", "th", "th", "th", "th", "th", "th",
+		"st", "nd", "rd", "th", "th", "th", "th", "th", "th", "th",
+		"st",
+	}
+
+	for d := 1; d < len(suffixes); d++ {
+		base := time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC)
+		want := strconv.Itoa(d) + suffixes[d]
+		if got := strftime.Format("%-d%o", base); got != want {
+			t.Errorf("Format(%q) = %q, want %q", "%-d%o", got, want)
+		}
+		if got := strftime.Format("%o", base); got != suffixes[d] {
+			t.Errorf("Format(%q) = %q, want %q", "%o", got, suffixes[d])
+		}
+	}
+
+	if got := strftime.Format("%B %-d%o, the %-j%o day of %Y", reference); got != "August 7th, the 219th day of 2009" {
+		t.Errorf("Format(%q) = %q", "%B %-d%o, the %-j%o day of %Y", got)
+	}
+	if got := strftime.Format("the %-I%o hour and %-M%o minute", reference); got != "the 6th hour and 5th minute" {
+		t.Errorf("Format(%q) = %q", "the %-I%o hour and %-M%o minute", got)
+	}
+}
+
+func TestFormat_Days(t *testing.T) {
+	tests := []struct {
+		time time.Time
+		want string
+	}{
+		{time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), "2451545 2451545.0 51544 51544.5 36526 36526.5"},
+		{time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "2451545 2451544.5 51544 51544.0 36526 36526.0"},
+		{time.Date(2000, 1, 1, 18, 0, 0, 0, time.UTC), "2451545 2451545.25 51544 51544.75 36526 36526.75"},
+		{time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC), "2400001 2400000.5 0 0.0 -15018 -15018.0"},
+		{time.Date(1899, 12, 29, 12, 0, 0, 0, time.UTC), "2415018 2415018.0 15017 15017.5 -1 -1.5"},
+		{time.Date(1899, 12, 29, 6, 0, 0, 0, time.UTC), "2415018 2415017.75 15017 15017.25 -1 -1.25"},
+		{time.Date(1899, 12, 30, 6, 0, 0, 0, time.UTC), "2415019 2415018.75 15018 15018.25 0 0.25"},
+		{time.Date(-4713, 11, 24, 12, 0, 0, 0, time.UTC), "0 0.0 -2400001 -2400000.5 -2415019 -2415019.5"},
+		{time.Date(-4713, 11, 24, 0, 0, 0, 0, time.UTC), "0 -0.5 -2400001 -2400001.0 -2415019 -2415019.0"},
+		{time.Date(2009, 8, 7, 0, 0, 0, 1, time.UTC), "2455051 2455050.50000000000001 55050 55050.00000000000001 40032 40032.00000000000001"},
+	}
+
+	for _, test := range tests {
+		if got := strftime.Format("%J %:J %K %:K %i %:i", test.time); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.time, got, test.want)
+		}
+	}
+}
+
+func TestParse_Days(t *testing.T) {
+	base := reference
+	for i := 0; i < 1000; i++ {
+		base = base.Add(-7777777777777777)
+		for _, format := range []string{"%:J", "%:K", "%:i", "%J %T.%N", "%K %T.%N", "%i %T.%N"} {
+			value := strftime.Format(format, base)
+			if got, err := strftime.Parse(format, value); err != nil {
+				t.Fatalf("Parse(%q, %q) = %v", format, value, err)
+			} else if !got.Equal(base) {
+				t.Fatalf("Parse(%q, %q) = %v, want %v", format, value, got, base)
+			}
+		}
+	}
+
+	if got, err := strftime.Parse("%:J", "2451545.25"); err != nil {
+		t.Error(err)
+	} else if want := time.Date(2000, 1, 1, 18, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Parse(%q) = %v, want %v", "2451545.25", got, want)
+	}
+	jst := time.FixedZone("JST", 9*3600)
+	if got := strftime.Format("%J %:J %K %:K %i %:i", time.Date(2000, 1, 1, 21, 0, 0, 0, jst)); got != "2451545 2451545.0 51544 51544.5 36526 36526.875" {
+		t.Errorf("Format() = %q", got)
+	}
+	if got, err := strftime.Parse("%:J", "2451545.0"); err != nil {
+		t.Error(err)
+	} else if want := time.Date(2000, 1, 1, 21, 0, 0, 0, jst); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("Parse(%q) = %v, want %v", "2451545.0", got, want)
+	}
+	if got, err := strftime.Parse("%:i", "-1.25"); err != nil {
+		t.Error(err)
+	} else if want := time.Date(1899, 12, 29, 6, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Parse(%q) = %v, want %v", "-1.25", got, want)
+	}
+	if got, err := strftime.Parse("%i %H:%M", "36526 18:30"); err != nil {
+		t.Error(err)
+	} else if want := time.Date(2000, 1, 1, 18, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Parse(%q) = %v, want %v", "36526 18:30", got, want)
+	}
+}
+
 func TestParse(t *testing.T) {
 	for _, test := range timeTests {
 		if got, err := strftime.Parse(test.format, test.time); err != nil && test.layout != "" {
@@ -224,6 +423,13 @@ func TestParse_Table(t *testing.T) {
 		{"%FT%T%:z", "2009-8-7T6:5:4.3Z"},
 		{"%r %D", "06:05:04.3 AM 08/07/09"},
 		{"%r %D", "6:5:4.3 AM 8/7/09"},
+		{"%s.%L", "1249625104.300"},
+		{"%Q", "1249625104300"},
+		{"%G-W%V-%u %k:%M:%S.%L", "2009-W32-5  6:05:04.300"},
+		{"%C%y-%j %l:%M:%S.%N %P", "2009-219  6:05:04.300000000 am"},
+		{"%B %-d%o, %Y %T.%L", "August 7th, 2009 06:05:04.300"},
+		{"%B %-d%o, %Y %T.%L", "AUGUST 7TH, 2009 06:05:04.300"},
+		{"the %-d%o of %B, %Y %T.%L", "the 7th of August, 2009 06:05:04.300"},
 	}
 
 	for _, test := range parseTests {
@@ -235,6 +441,127 @@ func TestParse_Table(t *testing.T) {
 	}
 }
 
+func TestParse_Ordinal(t *testing.T) {
+	for _, value := range []string{"March 3th", "March 1nd", "March 3"} {
+		if got, err := strftime.Parse("%B %-d%o", value); err == nil {
+			t.Errorf("Parse(%q) = %v", value, got)
+		}
+	}
+	for _, value := range []string{"March 1st", "March 22nd", "March 13th"} {
+		if _, err := strftime.Parse("%B %-d%o", value); err != nil {
+			t.Errorf("Parse(%q) = %v", value, err)
+		}
+	}
+}
+
+func TestParse_Sections(t *testing.T) {
+	const format = "%FT%R%[:%S%[.%L%]%]%[Z%|%:z%]"
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2024-03-03T10:00", time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)},
+		{"2024-03-03T10:00:01", time.Date(2024, 3, 3, 10, 0, 1, 0, time.UTC)},
+		{"2024-03-03T10:00:01.500", time.Date(2024, 3, 3, 10, 0, 1, 500000000, time.UTC)},
+		{"2024-03-03T10:00Z", time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)},
+		{"2024-03-03T10:00:01.500+02:00", time.Date(2024, 3, 3, 8, 0, 1, 500000000, time.UTC)},
+	}
+
+	for _, test := range tests {
+		for _, mode := range []strftime.Mode{strftime.DefaultMode, strftime.StrictMode} {
+			p := strftime.Parser{Mode: mode}
+			got, err := p.Parse(format, test.value)
+			if err != nil {
+				t.Errorf("Parse(%q, %q) error = %v", format, test.value, err)
+			} else if !got.Equal(test.want) {
+				t.Errorf("Parse(%q, %q) = %v, want %v", format, test.value, got, test.want)
+			}
+		}
+	}
+
+	for _, value := range []string{"2024-03-03T10", "2024-03-03T10:00:", "2024-03-03T10:00+02", "2024-03-03T10:00Zx"} {
+		if got, err := strftime.Parse(format, value); err == nil {
+			t.Errorf("Parse(%q, %q) = %v, want error", format, value, got)
+		}
+	}
+
+	if got, err := strftime.Parse("%[%Y%|%y%]-%m", "24-03"); err != nil || got.Year() != 2024 {
+		t.Errorf("Parse() = (%v, %v)", got, err)
+	}
+	if got, err := strftime.Parse("%d %[%b%[ %Y%]%]", "03 Mar"); err != nil || got.Month() != time.March {
+		t.Errorf("Parse() = (%v, %v)", got, err)
+	}
+	if got, err := strftime.Parse("%H%[:%M", "10%[:30"); err != nil || got.Minute() != 30 {
+		t.Errorf("Parse() = (%v, %v)", got, err)
+	}
+	if got, err := strftime.Parse("%[%H%|%H:%M%]", "10:30"); err != nil || got.Minute() != 30 {
+		t.Errorf("Parse() = (%v, %v)", got, err)
+	}
+
+	// Sections are scanned once, so failing takes linear time.
+	long := strings.Repeat("%[x%|x%|%]", 50) + "y"
+	if got, err := strftime.Parse(long, strings.Repeat("x", 50)+"z"); err == nil {
+		t.Errorf("Parse() = %v, want error", got)
+	}
+}
+
+func TestFormat_Sections(t *testing.T) {
+	tests := []struct {
+		format, want string
+	}{
+		{"%FT%R%[:%S%[.%L%]%]%[Z%|%:z%]", "2009-08-07T06:05:04.300Z"},
+		{"%[%Y%|%y%]-%m", "2009-08"},
+		{"%H%[:%M%|%[x%]%|y%]:%S", "06:05:04"},
+		{"%H%[:%M", "06%[:05"},
+		{"%H%|%M%]", "06%|05%]"},
+		{"%[", "%["},
+		{"%[%[x%]", "%[x"},
+		{"%[%%]", "%[%]"},
+		{"%[%:2%]", "%[%:2%]"},
+		{"%[%:2%]%]", "%:2%]"},
+	}
+
+	for _, test := range tests {
+		if got := strftime.Format(test.format, reference); got != test.want {
+			t.Errorf("Format(%q) = %q, want %q", test.format, got, test.want)
+		}
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		format, value string
+		want          time.Time
+		n             int
+	}{
+		{"%F %T", "2024-03-03 10:00:01 GET /index.html", time.Date(2024, 3, 3, 10, 0, 1, 0, time.UTC), 19},
+		{"%F %T", "2024-03-03 10:00:01.500 GET", time.Date(2024, 3, 3, 10, 0, 1, 500000000, time.UTC), 23},
+		{"%b %e %T", "Mar  3 10:00:01 host sshd[42]: ok", time.Date(0, 3, 3, 10, 0, 1, 0, time.UTC), 15},
+		{"[%d/%b/%Y:%T %z]", "[03/Mar/2024:10:00:01 +0100] \"GET /\"", time.Date(2024, 3, 3, 9, 0, 1, 0, time.UTC), 28},
+		{"%FT%R%[:%S%]", "2024-03-03T10:00:01Z", time.Date(2024, 3, 3, 10, 0, 1, 0, time.UTC), 19},
+		{"%FT%R%[:%S%]", "2024-03-03T10:00Z", time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC), 16},
+		{"%s", "1709460001", time.Unix(1709460001, 0), 10},
+		{"%[%H%|%H:%M%]", "10:30 x", time.Date(0, 1, 1, 10, 30, 0, 0, time.UTC), 5},
+	}
+
+	for _, test := range tests {
+		got, n, err := strftime.ParsePrefix(test.format, test.value)
+		if err != nil {
+			t.Errorf("ParsePrefix(%q, %q) error = %v", test.format, test.value, err)
+		} else if !got.Equal(test.want) || n != test.n {
+			t.Errorf("ParsePrefix(%q, %q) = (%v, %d), want (%v, %d)", test.format, test.value, got, n, test.want, test.n)
+		}
+	}
+
+	for _, value := range []string{"2024-03-0", "2024-02-30 10:00:01 x", "x 2024-03-03 10:00:01"} {
+		if got, n, err := strftime.ParsePrefix("%F %T", value); err == nil || n != 0 {
+			t.Errorf("ParsePrefix(%q) = (%v, %d, %v), want error", value, got, n, err)
+		} else if err, ok := err.(*time.ParseError); !ok || err.Value != value {
+			t.Errorf("ParsePrefix(%q) error = %#v, want Value %q", value, err, value)
+		}
+	}
+}
+
 func TestLayout(t *testing.T) {
 	for _, test := range timeTests {
 		if got, err := strftime.Layout(test.format); err != nil && test.layout != "" {
@@ -245,6 +572,9 @@ func TestLayout(t *testing.T) {
 			t.Logf("Layout(%q) = %v", test.format, err)
 		}
 	}
+	if got, err := strftime.Layout("x%[y%|z%]w"); err == nil {
+		t.Errorf("Layout() = %q, want error", got)
+	}
 }
 
 func TestLayout_Format(t *testing.T) {
@@ -268,4 +598,7 @@ func TestUTS35(t *testing.T) {
 			t.Logf("UTS35(%q) = %v", test.format, err)
 		}
 	}
+	if got, err := strftime.UTS35("x%[y%|z%]w"); err == nil {
+		t.Errorf("UTS35() = %q, want error", got)
+	}
 }
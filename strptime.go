@@ -0,0 +1,1365 @@
+package strftime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// item is either a literal, or a directive of a compiled format.
+// Optional sections are directives with alternatives.
+type item struct {
+	lit             string
+	spec, flag, mod byte
+	width           int
+	alts            [][]item
+}
+
+func (i item) String() string {
+	if i.spec == 0 {
+		return i.lit
+	}
+	if i.spec == '[' {
+		var s strings.Builder
+		for n, alt := range i.alts {
+			if n == 0 {
+				s.WriteString("%[")
+			} else {
+				s.WriteString("%|")
+			}
+			for _, it := range alt {
+				s.WriteString(it.String())
+			}
+		}
+		s.WriteString("%]")
+		return s.String()
+	}
+	s := []byte{'%'}
+	if i.flag != 0 {
+		s = append(s, i.flag)
+	}
+	if i.width != 0 {
+		s = strconv.AppendInt(s, int64(i.width), 10)
+	}
+	if i.mod != 0 {
+		s = append(s, i.mod)
+	}
+	return string(append(s, i.spec))
+}
+
+func compile(fmt string) ([]item, error) {
+	var items []item
+	var parser parser
+
+	// The items and alternatives enclosing the current section.
+	type section struct {
+		items []item
+		alts  [][]item
+	}
+	var sections []section
+
+	parser.section = func(b byte) error {
+		switch b {
+		case '[':
+			sections = append(sections, section{items: items})
+		case '|':
+			s := &sections[len(sections)-1]
+			s.alts = append(s.alts, items)
+		case ']':
+			s := sections[len(sections)-1]
+			sections = sections[:len(sections)-1]
+			items = append(s.items, item{spec: '[', alts: append(s.alts, items)})
+			return nil
+		}
+		items = nil
+		return nil
+	}
+
+	parser.literal = func(b byte) error {
+		if n := len(items) - 1; n >= 0 && items[n].spec == 0 {
+			items[n].lit += string([]byte{b})
+		} else {
+			items = append(items, item{lit: string([]byte{b})})
+		}
+		return nil
+	}
+
+	parser.format = func(spec, flag byte) error {
+		switch spec {
+		case '%':
+			return parser.literal('%')
+		case 't':
+			return parser.literal('\t')
+		case 'n':
+			return parser.literal('\n')
+		}
+		if fmt := combination(spec); fmt != "" {
+			return parser.parse(fmt)
+		}
+		if !okParse(spec, flag) {
+			return formatError{}
+		}
+		items = append(items, item{spec: spec, flag: flag, mod: parser.modifier, width: parser.width})
+		return nil
+	}
+
+	if err := parser.parse(fmt); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// parse parses value, or a prefix of it,
+// and returns the length of what it parsed.
+func parse(fmt, value string, p *Parser, prefix bool) (time.Time, int, error) {
+	f, n, err := scan(fmt, value, p, prefix)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	t, err := f.time(p.locale())
+	if err != nil {
+		return time.Time{}, 0, &time.ParseError{
+			Layout:  fmt,
+			Value:   value,
+			Message: ": " + err.Error(),
+		}
+	}
+	return t, n, nil
+}
+
+// scan parses value, or a prefix of it, into fields,
+// and returns the length of what it parsed.
+func scan(fmt, value string, p *Parser, prefix bool) (*fields, int, error) {
+	items, err := compile(fmt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	f := p.fields()
+	rest, err := f.scan(items, value, p.locale())
+	if err == nil && rest != "" && !prefix {
+		err = &time.ParseError{Message: ": extra text: " + strconv.Quote(rest)}
+	}
+	if err != nil {
+		if err, ok := err.(*time.ParseError); ok {
+			err.Layout = fmt
+			err.Value = value
+		}
+		return nil, 0, err
+	}
+	return &f, len(value) - len(rest), nil
+}
+
+// fields returns the fields to parse into with the parser options.
+func (p *Parser) fields() fields {
+	return fields{
+		ref:           p.Reference,
+		closest:       p.Closest,
+		twoDigitYears: p.TwoDigitYears,
+		validate:      p.Validate,
+		leapSecond:    p.LeapSecond,
+		mode:          p.Mode,
+		number:        -1,
+	}
+}
+
+// fields records the values of the parsed directives.
+type fields struct {
+	year, century   int
+	era, yoe        int
+	altEra          int
+	month, day      int
+	monthName       string // in a calendar, resolved once the year is known
+	abbr            bool
+	yday            int
+	wday            time.Weekday
+	hour, min, sec  int
+	nsec            int
+	week            [5]int // %U %W %V %:V %:W
+	isoYear, wkYear int
+	wdayInMonth     int
+	unix            int64
+	offset          int
+	zone            string
+	loc             *time.Location
+	set             fieldSet
+
+	normalize bool      // normalize out of range values, as mktime does
+	validate  bool      // check redundant fields against the time
+	ref       time.Time // fills missing fields, if not zero
+	closest   bool      // fill missing fields to the time closest to ref
+
+	twoDigitYears func(yy, ref int) int
+	leapSecond    LeapSecond
+	mode          Mode
+
+	number int // the last number scanned, for %o, or -1
+}
+
+type fieldSet uint32
+
+const (
+	setYear fieldSet = 1 << iota
+	setYear2
+	setCentury
+	setEra
+	setYearOfEra
+	setAltEra
+	setMonth
+	setDay
+	setYearDay
+	setWeekday
+	setHour
+	setAM
+	setPM
+	setSundayWeek
+	setMondayWeek
+	setISOWeek
+	setISOYear
+	setLocaleWeek
+	setLocaleYear
+	setMonthWeek
+	setWeekdayInMonth
+	setUnix
+	setOffset
+	setZone
+	setMinute
+	setSecond
+	setNanosecond
+	set24Hour
+)
+
+const (
+	sundayWeek = iota
+	mondayWeek
+	isoWeek
+	localeWeek
+	monthWeek
+)
+
+var weekSet = [...]fieldSet{
+	sundayWeek: setSundayWeek,
+	mondayWeek: setMondayWeek,
+	isoWeek:    setISOWeek,
+	localeWeek: setLocaleWeek,
+	monthWeek:  setMonthWeek,
+}
+
+func (f *fields) has(s fieldSet) bool {
+	return f.set&s != 0
+}
+
+func (f *fields) scan(items []item, value string, l *Locale) (string, error) {
+	return f.scanN(items, len(items), value, l)
+}
+
+// scanN scans the first n of items;
+// the following ones are only looked at.
+func (f *fields) scanN(items []item, n int, value string, l *Locale) (string, error) {
+	for i := 0; i < n; i++ {
+		if items[i].spec == '[' {
+			value = f.scanSection(items[i].alts, items[i+1:], value, l)
+			continue
+		}
+		rest, err := f.scanItem(items[i:], value, l)
+		if err != nil {
+			return rest, err
+		}
+		value = rest
+	}
+	return value, nil
+}
+
+// scanItem scans the first of items;
+// the following ones are only looked at.
+func (f *fields) scanItem(items []item, value string, l *Locale) (string, error) {
+	it := items[0]
+	if it.spec == 0 {
+		rest, ok := getliteral(value, it.lit, f.mode == LenientMode)
+		if !ok {
+			return value, syntaxError(it, value)
+		}
+		return rest, nil
+	}
+
+	var n int
+	var ok bool
+	var rng string
+
+	if f.mode == LenientMode && numeric(it, l) {
+		value = strings.TrimLeft(value, spaces)
+	}
+
+	// Alternative digits are replaced before parsing numbers.
+	var offsets []int
+	orig := value
+	if l.AltDigits != (Digits{}) && numeric(it, l) {
+		value, offsets = l.AltDigits.ascii(value)
+	}
+	ascii := value
+	rest := value
+
+	if f.mode == StrictMode && !padded(it, value) {
+		return orig, syntaxError(it, orig)
+	}
+
+	// A dot can follow abbreviated names, unless the format has one.
+	dot := f.mode != StrictMode &&
+		(len(items) == 1 || !strings.HasPrefix(items[1].lit, "."))
+
+	switch it.spec {
+	case 'Y':
+		if it.mod == 'E' && len(l.Eras) > 0 {
+			// Years before the first era are Gregorian years.
+			saved := *f
+			if rest, err := f.scanEraYear(value, l); err == nil {
+				return rest, nil
+			}
+			*f = saved
+			return f.scanItem(unmodified(items), value, l)
+		}
+		if it.flag == ':' {
+			f.year, rest, ok = getyear(value, expandedYear(it.width), adjacent(items[1:]), true)
+		} else {
+			exact := adjacent(items[1:])
+			f.year, rest, ok = getyear(value, f.minDigits(4, exact), exact, false)
+		}
+		f.set |= setYear
+	case 'C':
+		if it.mod == 'E' && len(l.Eras) > 0 {
+			// Years before the first era have a Gregorian century.
+			if f.altEra, rest, ok = lookup(value, l.altEraNames()); ok {
+				f.set |= setAltEra
+				break
+			}
+			return f.scanItem(unmodified(items), value, l)
+		}
+		if it.flag == ':' {
+			f.era, rest, ok = l.lookupName(value, l.eraNames(), false, false)
+			f.set |= setEra
+			break
+		}
+		if adjacent(items[1:]) {
+			f.century, rest, ok = getyear(value, 2, true, false)
+		} else {
+			f.century, rest, ok = getyear(value, 1, false, false)
+		}
+		f.set |= setCentury
+	case 'y':
+		if it.mod == 'E' && len(l.Eras) > 0 {
+			if rest, ok = getFirstEraYear(value, l); ok {
+				f.yoe = 1
+			} else {
+				f.yoe, rest, ok = getnum(value, 1, 4)
+			}
+			f.set |= setYearOfEra
+			break
+		}
+		if it.flag == ':' {
+			f.yoe, rest, ok = getnum(value, 1, 10)
+			if f.yoe < 1 {
+				rng = "year"
+			}
+			f.set |= setYearOfEra
+			break
+		}
+		f.year, rest, ok = getnum(value, f.minDigits(2, false), 2)
+		f.set |= setYear2
+	case 'G', 'g':
+		if it.spec == 'G' {
+			exact := adjacent(items[1:])
+			n, rest, ok = getyear(value, f.minDigits(4, exact), exact, false)
+		} else {
+			n, rest, ok = getnum(value, f.minDigits(2, false), 2)
+			n = f.expandYear(n)
+		}
+		if it.flag == ':' {
+			f.wkYear = n
+			f.set |= setLocaleYear
+		} else {
+			f.isoYear = n
+			f.set |= setISOYear
+		}
+
+	case 'm':
+		f.month, rest, ok = getnum(value, 1, 2)
+		if f.month < 1 || f.month > 12 && (l.Calendar == nil || f.month > 13) {
+			rng = "month"
+		}
+		f.monthName = ""
+		f.set |= setMonth
+	case 'B', 'b', 'h':
+		abbr := it.spec != 'B'
+		if !l.gregorianMonths() {
+			rest, ok = f.getMonthName(value, l.Calendar, abbr)
+			break
+		}
+		n, rest, ok = l.lookupName(value, l.monthNames(abbr), abbr, dot)
+		f.month = n%12 + 1
+		f.monthName = ""
+		f.set |= setMonth
+
+	case 'd', 'e':
+		if it.spec == 'e' {
+			value = strings.TrimPrefix(value, " ")
+		}
+		f.day, rest, ok = getnum(value, 1, 2)
+		if f.day < 1 || f.day > 31 {
+			rng = "day"
+		}
+		f.set |= setDay
+	case 'j':
+		if it.flag == '-' {
+			for i := 0; i < 2 && strings.HasPrefix(value, " "); i++ {
+				value = value[1:]
+			}
+			f.yday, rest, ok = getnum(value, 1, 3)
+		} else {
+			f.yday, rest, ok = getnum(value, f.minDigits(3, false), 3)
+		}
+		if f.yday < 1 || f.yday > 366 && l.Calendar == nil {
+			rng = "day-of-year"
+		}
+		f.set |= setYearDay
+
+	case 'A':
+		n, rest, ok = l.lookupName(value, l.days(false), false, false)
+		f.wday = time.Weekday(n)
+		f.set |= setWeekday
+	case 'a':
+		n, rest, ok = l.lookupName(value, l.days(true), true, dot)
+		f.wday = time.Weekday(n)
+		f.set |= setWeekday
+	case 'w':
+		n, rest, ok = getnum(value, 1, 1)
+		if it.flag == ':' {
+			if n < 1 || n > 5 {
+				rng = "weekday in month"
+			}
+			f.wdayInMonth = n
+			f.set |= setWeekdayInMonth
+			break
+		}
+		if n > 6 {
+			rng = "weekday"
+		}
+		f.wday = time.Weekday(n)
+		f.set |= setWeekday
+	case 'u':
+		n, rest, ok = getnum(value, 1, 1)
+		if n < 1 || n > 7 {
+			rng = "weekday"
+		}
+		if it.flag == ':' {
+			f.wday = (l.firstDay() + time.Weekday(n) - 1) % 7
+		} else {
+			f.wday = time.Weekday(n % 7)
+		}
+		f.set |= setWeekday
+
+	case 'U', 'W', 'V':
+		n, rest, ok = getnum(value, 1, 2)
+		kind := isoWeek
+		switch {
+		case it.spec == 'W' && it.flag == ':':
+			kind = monthWeek
+		case it.spec == 'U':
+			kind = sundayWeek
+		case it.spec == 'W':
+			kind = mondayWeek
+		case it.flag == ':':
+			kind = localeWeek
+		}
+		max := 53
+		if l.Calendar != nil && (kind == sundayWeek || kind == mondayWeek) {
+			max = 55 // Hebrew leap years have up to 385 days
+		}
+		if n > max || n < 1 && (kind == isoWeek || kind == localeWeek) || n > 6 && kind == monthWeek {
+			rng = "week"
+		}
+		f.week[kind] = n
+		f.set |= weekSet[kind]
+
+	case 'H', 'k':
+		if it.spec == 'k' {
+			value = strings.TrimPrefix(value, " ")
+		}
+		f.hour, rest, ok = getnum(value, 1, 2)
+		if f.hour > 23 {
+			rng = "hour"
+		}
+		f.set |= setHour | set24Hour
+	case 'I', 'l':
+		if it.spec == 'l' {
+			value = strings.TrimPrefix(value, " ")
+		}
+		f.hour, rest, ok = getnum(value, 1, 2)
+		if f.hour > 12 {
+			rng = "hour"
+		}
+		f.set = f.set&^set24Hour | setHour
+	case 'p', 'P':
+		n, rest, ok = l.lookupName(value, l.dayPeriods(), false, false)
+		if n == 0 {
+			f.set = f.set&^setPM | setAM
+		} else {
+			f.set = f.set&^setAM | setPM
+		}
+
+	case 'M':
+		f.min, rest, ok = getnum(value, 1, 2)
+		if f.min > 59 {
+			rng = "minute"
+		}
+		f.set |= setMinute
+	case 'S':
+		f.sec, rest, ok = getnum(value, 1, 2)
+		if f.sec > 59 && (f.sec > 60 || f.leapSecond == RejectLeapSecond) {
+			rng = "second"
+		}
+		if ok && f.mode != StrictMode && !fractionFollows(items[1:]) {
+			if nsec, frac := getfrac(rest); frac != rest {
+				f.nsec, rest = nsec, frac
+				f.set |= setNanosecond
+			}
+		}
+		f.set |= setSecond
+	case 'L', 'f', 'N':
+		digits := 9
+		switch it.spec {
+		case 'L':
+			digits = 3
+		case 'f':
+			digits = 6
+		}
+		if _, rest, ok = getnum(value, digits, digits); ok {
+			f.nsec, _ = getfrac("." + value[:digits])
+		}
+		f.set |= setNanosecond
+
+	case 'J', 'K', 'i':
+		var date time.Time
+		date, rest, ok = getdays(value, it.spec, it.flag == ':')
+		switch {
+		case it.flag == ':' && it.spec != 'i':
+			// Julian and Modified Julian Dates are instants in Universal Time.
+			f.unix, f.nsec = date.Unix(), date.Nanosecond()
+			f.set |= setUnix
+		case it.flag == ':':
+			f.setDate(date, l)
+			f.setClock(date)
+		default:
+			f.setDate(date, l)
+		}
+
+	case 's', 'Q':
+		var unix int64
+		unix, rest, ok = getint64(value)
+		if it.spec == 's' {
+			f.unix, f.nsec = unix, 0
+		} else {
+			f.unix, f.nsec = unix/1e3, int(unix%1e3)*1e6
+			if f.nsec < 0 {
+				f.unix, f.nsec = f.unix-1, f.nsec+1e9
+			}
+		}
+		f.set |= setUnix
+
+	case 'o':
+		rest, ok = getordinal(value, f.number, l)
+
+	case 'z':
+		if strings.HasPrefix(value, "Z") {
+			f.loc = time.UTC
+			f.set &^= setOffset
+			rest, ok = value[1:], true
+			break
+		}
+		f.offset, rest, ok = getoffset(value, it.flag == ':')
+		f.loc = nil
+		f.set |= setOffset
+	case 'Z':
+		n, ok = getzone(value)
+		if ok {
+			f.zone, rest = value[:n], value[n:]
+			f.set |= setZone
+		}
+	}
+
+	number := value[:len(value)-len(rest)]
+	if offsets != nil {
+		value, rest = orig, unascii(orig, ascii, rest, offsets)
+	}
+	if !ok {
+		return value, syntaxError(it, value)
+	}
+	if rng != "" {
+		return value, &time.ParseError{
+			LayoutElem: it.String(),
+			ValueElem:  value,
+			Message:    ": " + rng + " out of range",
+		}
+	}
+	if n, ok := atoi(number); ok {
+		f.number = n
+	}
+	return rest, nil
+}
+
+// scanSection scans an optional section, given the items that follow it,
+// which are only looked at.
+// It uses the alternative that scans the most of value (the first, if several do),
+// or none of them, if none scans; it never backtracks into the section.
+func (f *fields) scanSection(alts [][]item, items []item, value string, l *Locale) string {
+	best, rest := *f, value
+	var found bool
+	for _, alt := range alts {
+		g := *f
+		r, err := g.scanN(append(alt[:len(alt):len(alt)], items...), len(alt), value, l)
+		if err == nil && (!found || len(r) < len(rest)) {
+			best, rest, found = g, r, true
+		}
+	}
+	*f = best
+	return rest
+}
+
+// scanEraYear scans the alternative year representation (%EY) of the locale.
+func (f *fields) scanEraYear(value string, l *Locale) (string, error) {
+	items, err := compile(l.eraYear())
+	if err != nil {
+		return value, err
+	}
+	for i := range items {
+		if items[i].spec == 'Y' {
+			items[i].mod = 0 // avoid recursion
+		}
+	}
+	return f.scan(items, value, l)
+}
+
+func (f *fields) setDate(t time.Time, l *Locale) {
+	f.year, f.month, f.day = l.date(t)
+	f.monthName = ""
+	f.set |= setYear | setMonth | setDay
+}
+
+// getMonthName matches a month name of a calendar.
+// Names may depend on the year (e.g. leap months), which may not be known yet,
+// so names are matched against those of a whole cycle of years,
+// and resolved to a month once the year is known.
+func (f *fields) getMonthName(s string, cal Calendar, abbr bool) (rest string, ok bool) {
+	var names []string
+	seen := map[string]bool{}
+	for y := 0; y < nameCycle; y++ {
+		for m := 1; m <= cal.MonthsIn(y); m++ {
+			if name := cal.MonthName(y, m, abbr); !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	_, rest, ok = lookup(s, names)
+	f.monthName = s[:len(s)-len(rest)]
+	f.abbr = abbr
+	f.set |= setMonth
+	return rest, ok
+}
+
+// nameCycle is the number of years in which month names go through all their variants:
+// the 19 years of the Metonic cycle of leap months.
+const nameCycle = 19
+
+func (f *fields) setClock(t time.Time) {
+	f.hour, f.min, f.sec = t.Clock()
+	f.nsec = t.Nanosecond()
+	f.set = f.set&^(setAM|setPM) | setHour | set24Hour | setMinute | setSecond | setNanosecond
+}
+
+// time converts the parsed fields to a time.Time,
+// filling missing fields from the reference time, if any.
+func (f *fields) time(l *Locale) (time.Time, error) {
+	if f.ref.IsZero() || f.has(setUnix) {
+		return f.checkedTime(l)
+	}
+	if !f.closest {
+		g := f.withReference(0, l)
+		return g.checkedTime(l)
+	}
+
+	// Try the periods around the reference (e.g. the previous and next year),
+	// and pick the closest time.
+	var best time.Time
+	var first error
+	for _, n := range []int{0, -1, 1} {
+		g := f.withReference(n, l)
+		t, err := g.checkedTime(l)
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		if best.IsZero() || absDuration(t.Sub(f.ref)) < absDuration(best.Sub(f.ref)) {
+			best = t
+		}
+	}
+	if best.IsZero() {
+		return time.Time{}, first
+	}
+	return best, nil
+}
+
+func (f *fields) checkedTime(l *Locale) (time.Time, error) {
+	t, used, err := f.resolve(l)
+	if err == nil && f.validate {
+		err = f.check(t, used, l)
+	}
+	return t, err
+}
+
+// Fields that determine the year, or the date.
+const (
+	yearFields = setYear | setYear2 | setCentury | setEra | setYearOfEra | setAltEra |
+		setISOYear | setLocaleYear
+	dateFields = setMonth | setDay | setYearDay | setWeekday | setSundayWeek | setMondayWeek |
+		setISOWeek | setLocaleWeek | setMonthWeek | setWeekdayInMonth
+)
+
+// withReference returns the fields, with those missing filled from the reference time,
+// moved by n periods of the greatest missing field (years, months, days, or weeks).
+func (f fields) withReference(n int, l *Locale) fields {
+	if f.loc == nil && !f.has(setOffset|setZone) {
+		f.loc = f.ref.Location()
+	}
+
+	year, month, _ := l.date(f.ref)
+	switch {
+	case !f.has(yearFields | dateFields&^setWeekday):
+		// Only the time, and maybe the weekday, are known.
+		date := f.ref.AddDate(0, 0, n)
+		if f.has(setWeekday) {
+			date = f.ref.AddDate(0, 0, int(f.wday-f.ref.Weekday()+7)%7+7*n)
+		}
+		f.year, f.month, f.day = l.date(date)
+		f.monthName = ""
+		f.set |= setYear | setMonth | setDay
+	case !f.has(yearFields) && !f.has(setMonth|setYearDay) && f.has(setDay):
+		f.year, f.month = normalizeMonth(l.calendar(), year, month+n)
+		f.set |= setYear | setMonth
+	case !f.has(yearFields):
+		f.year = year + n
+		f.set |= setYear
+	case !f.has(setMonth|setYearDay) && f.has(setDay):
+		f.month = month
+		f.set |= setMonth
+	}
+	return f
+}
+
+// resolve converts the parsed fields to a time.Time,
+// and returns the fields used to determine its date.
+func (f *fields) resolve(l *Locale) (time.Time, fieldSet, error) {
+	if f.has(setUnix) {
+		t := time.Unix(f.unix, int64(f.nsec))
+		switch {
+		case f.loc != nil:
+			return t.In(f.loc), setUnix, nil
+		case f.has(setOffset):
+			return t.In(time.FixedZone(f.zone, f.offset)), setUnix, nil
+		}
+		return t.UTC(), setUnix, nil
+	}
+
+	if f.has(setYearOfEra) && f.yoe < 1 && (f.has(setAltEra) || !f.has(setCentury)) {
+		return time.Time{}, 0, errorString("year of era out of range")
+	}
+	year := f.getYear(l)
+	var date time.Time
+	var used fieldSet
+	switch {
+	case f.has(setMonth|setDay|setMonthWeek|setWeekdayInMonth) || !f.has(setYearDay|setWeekday|
+		setSundayWeek|setMondayWeek|setISOWeek|setISOYear|setLocaleWeek|setLocaleYear):
+		cal := l.calendar()
+		month, err := f.calendarMonth(cal, year)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+		if f.normalize {
+			year, month = normalizeMonth(cal, year, month)
+		}
+		date = cal.Time(year, month, 1)
+		used = setMonth | setDay
+		switch {
+		case f.has(setDay):
+			date = date.AddDate(0, 0, f.day-1)
+		case f.has(setWeekdayInMonth):
+			day := 7 * (f.wdayInMonth - 1)
+			if f.has(setWeekday) {
+				day += (int(f.wday) - int(date.Weekday()) + 7) % 7
+			}
+			date = date.AddDate(0, 0, day)
+			used |= setWeekdayInMonth | setWeekday
+		case f.has(setMonthWeek):
+			date = f.weekDate(weekOne(date, l.firstDay(), l.minDays()), monthWeek, l.firstDay())
+			used |= setMonthWeek | setWeekday
+		}
+		if y, m, _ := cal.Date(date); (m != month || y != year) && !f.normalize {
+			return time.Time{}, 0, errorString("day out of range")
+		}
+		if f.has(setYearDay) && l.yearDay(date) != f.yday {
+			return time.Time{}, 0, errorString("day-of-year does not match month/day")
+		}
+
+	case f.has(setYearDay):
+		cal := l.calendar()
+		date = cal.Time(year, 1, 1).AddDate(0, 0, f.yday-1)
+		if y, _, _ := cal.Date(date); y != year && !f.normalize {
+			return time.Time{}, 0, errorString("day-of-year out of range")
+		}
+		used = setYearDay
+
+	case f.has(setISOWeek | setISOYear):
+		if f.has(setISOYear) {
+			year = f.isoYear
+		}
+		date = f.weekDate(firstWeek(year, time.Monday, 4), isoWeek, time.Monday)
+		used = setISOWeek | setISOYear | setWeekday
+
+	case f.has(setLocaleWeek | setLocaleYear):
+		if f.has(setLocaleYear) {
+			year = f.wkYear
+		}
+		date = f.weekDate(l.weekStart(year), localeWeek, l.firstDay())
+		used = setLocaleWeek | setLocaleYear | setWeekday
+
+	case f.has(setSundayWeek):
+		date = f.weekDate(weekOne(l.yearStart(year), time.Sunday, 7), sundayWeek, time.Sunday)
+		used = setSundayWeek | setWeekday
+
+	case f.has(setMondayWeek):
+		date = f.weekDate(weekOne(l.yearStart(year), time.Monday, 7), mondayWeek, time.Monday)
+		used = setMondayWeek | setWeekday
+
+	default: // only the weekday is known
+		date = l.yearStart(year)
+		date = date.AddDate(0, 0, (int(f.wday)-int(date.Weekday())+7)%7)
+		used = setWeekday
+	}
+
+	hour := f.getHour()
+	sec, nsec := f.sec, f.nsec
+	if sec == 60 && f.leapSecond == ClampLeapSecond {
+		sec, nsec = 59, 999999999
+	}
+
+	y, m, d := date.Date()
+	t := time.Date(y, m, d, hour, f.min, sec, nsec, time.UTC)
+
+	switch {
+	case f.loc != nil:
+		return time.Date(y, m, d, hour, f.min, sec, nsec, f.loc), used, nil
+
+	case f.has(setOffset):
+		t = t.Add(-time.Duration(f.offset) * time.Second)
+		// Use the local zone, if it has the given offset at the given time.
+		if name, offset := t.In(time.Local).Zone(); offset == f.offset && (f.zone == "" || f.zone == name) {
+			return t.In(time.Local), used, nil
+		}
+		return t.In(time.FixedZone(f.zone, f.offset)), used, nil
+
+	case f.has(setZone):
+		if f.zone == "UTC" {
+			return t, used, nil
+		}
+		// Use the local zone, if it has the given abbreviation at the given time.
+		local := time.Date(y, m, d, hour, f.min, sec, nsec, time.Local)
+		if name, _ := local.Zone(); name == f.zone {
+			return local, used, nil
+		}
+		var offset int
+		if len(f.zone) > 3 && f.zone[:3] == "GMT" {
+			offset, _ = strconv.Atoi(f.zone[3:])
+			offset *= 3600
+		}
+		return t.Add(-time.Duration(offset) * time.Second).In(time.FixedZone(f.zone, offset)), used, nil
+	}
+	return t, used, nil
+}
+
+// check reports redundant fields, not used to determine the date,
+// that do not match the time.
+func (f *fields) check(t time.Time, used fieldSet, l *Locale) error {
+	set := f.set &^ used
+	has := func(s fieldSet) bool { return set&s != 0 }
+	epoch := used&setUnix != 0
+	year, month, day := l.date(t)
+	isoYear, isoWk := t.ISOWeek()
+	wkYear, wkWeek := l.week(t)
+	century, yy := splitYear(year)
+
+	switch {
+	case has(setCentury) && f.has(setYear) && !epoch:
+		if c, _ := splitYear(f.year); c != f.century {
+			return errorString("century does not match year")
+		}
+	case !epoch:
+	case has(setYear) && f.year != year,
+		has(setYear2) && f.year != yy,
+		has(setCentury) && f.century != century,
+		has(setMonth) && f.monthName == "" && f.month != month,
+		has(setDay) && f.day != day:
+		return errorString("date does not match epoch")
+	case has(setHour) && f.getHour() != t.Hour(),
+		has(setMinute) && f.min != t.Minute(),
+		has(setSecond) && f.sec != t.Second():
+		return errorString("time does not match epoch")
+	}
+
+	switch {
+	case f.has(set24Hour) && f.has(setAM|setPM) && (f.hour >= 12) != f.has(setPM):
+		return errorString("hour does not match AM/PM")
+	case f.has(setAltEra) && !l.inAltEra(t, f.altEra):
+		return errorString("date is outside the era")
+	case has(setYearDay) && f.yday != l.yearDay(t):
+		return errorString("day-of-year does not match date")
+	case has(setWeekday) && f.wday != t.Weekday():
+		return errorString("weekday does not match date")
+	case has(setWeekdayInMonth) && f.wdayInMonth != (day+6)/7:
+		return errorString("weekday in month does not match date")
+	case has(setISOYear) && f.isoYear != isoYear,
+		has(setLocaleYear) && f.wkYear != wkYear:
+		return errorString("week-based year does not match date")
+	case has(setSundayWeek) && f.week[sundayWeek] != l.yearWeek(t, time.Sunday),
+		has(setMondayWeek) && f.week[mondayWeek] != l.yearWeek(t, time.Monday),
+		has(setISOWeek) && f.week[isoWeek] != isoWk,
+		has(setLocaleWeek) && f.week[localeWeek] != wkWeek,
+		has(setMonthWeek) && f.week[monthWeek] != l.monthWeek(day, t.Weekday()):
+		return errorString("week does not match date")
+	}
+	return nil
+}
+
+// getHour returns the parsed hour of the day.
+func (f *fields) getHour() int {
+	switch {
+	case f.has(setPM) && f.hour < 12:
+		return f.hour + 12
+	case f.has(setAM) && f.hour == 12:
+		return 0
+	}
+	return f.hour
+}
+
+// getYear returns the parsed year.
+func (f *fields) getYear(l *Locale) int {
+	year := f.year
+	switch {
+	case f.has(setYear):
+	case f.has(setAltEra):
+		year = l.Eras[f.altEra].Start.Year()
+		if f.has(setYearOfEra) {
+			year += f.yoe - 1
+		}
+	case f.has(setYearOfEra) && f.has(setCentury) && !f.has(setEra):
+		year = f.century*100 + f.yoe // a year before the first era
+	case f.has(setYearOfEra):
+		year = f.yoe
+		if f.has(setEra) && f.era == 0 {
+			year = 1 - f.yoe
+		}
+	case f.has(setYear2) && f.has(setCentury):
+		year = f.century*100 + f.year
+	case f.has(setYear2):
+		year = f.expandYear(f.year)
+	case f.has(setCentury):
+		year = f.century * 100
+	}
+	return year
+}
+
+// calendarMonth returns the parsed month of the year in the calendar.
+func (f *fields) calendarMonth(cal Calendar, year int) (int, error) {
+	switch {
+	case f.monthName != "":
+		for m := 1; m <= cal.MonthsIn(year); m++ {
+			if strings.EqualFold(cal.MonthName(year, m, f.abbr), f.monthName) {
+				return m, nil
+			}
+		}
+		return 0, errorString("month name does not match year")
+	case !f.has(setMonth):
+		return 1, nil
+	case f.month > cal.MonthsIn(year) && !f.normalize:
+		return 0, errorString("month out of range")
+	}
+	return f.month, nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// normalizeMonth brings a month of the year within the year.
+func normalizeMonth(cal Calendar, year, month int) (int, int) {
+	for month > cal.MonthsIn(year) {
+		month -= cal.MonthsIn(year)
+		year++
+	}
+	for month < 1 {
+		year--
+		month += cal.MonthsIn(year)
+	}
+	return year, month
+}
+
+// weekDate returns the date of the parsed weekday, in the parsed week,
+// given the start of week 1, and the first day of the week.
+func (f *fields) weekDate(start time.Time, kind int, first time.Weekday) time.Time {
+	week := 1
+	if f.has(weekSet[kind]) {
+		week = f.week[kind]
+	}
+	var day int
+	if f.has(setWeekday) {
+		day = (int(f.wday) - int(first) + 7) % 7
+	}
+	return start.AddDate(0, 0, 7*(week-1)+day)
+}
+
+// unmodified returns items, with the modifier of the first one removed.
+func unmodified(items []item) []item {
+	it := items[0]
+	it.mod = 0
+	return append([]item{it}, items[1:]...)
+}
+
+// numeric reports if a directive is parsed as a number.
+func numeric(it item, l *Locale) bool {
+	if it.mod == 'E' && (it.spec == 'C' || it.spec == 'Y') && len(l.Eras) > 0 || it.flag == ':' && it.spec == 'C' {
+		return false // era names
+	}
+	return !strings.ContainsRune("aAbBhpPoZ", rune(it.spec))
+}
+
+// padded reports if a value starts with a number
+// padded as the directive formats it.
+func padded(it item, value string) bool {
+	var width int
+	var pad byte
+	switch it.spec {
+	case 'e', 'k', 'l':
+		width, pad = 2, ' '
+	case 'd', 'H', 'I', 'm', 'M', 'S', 'U', 'V', 'W':
+		if it.flag == '-' {
+			// Unpadded numbers have no leading zeros.
+			return isDigit(value, 0) && (value[0] != '0' || !isDigit(value, 1))
+		}
+		if it.flag == ':' && it.spec == 'W' {
+			return true
+		}
+		width, pad = 2, '0'
+	default:
+		return true
+	}
+
+	if len(value) < width || isDigit(value, width) {
+		return false
+	}
+	i := 0
+	for i < width-1 && value[i] == pad {
+		i++
+	}
+	if pad == ' ' && value[i] == '0' && i < width-1 {
+		return false
+	}
+	for ; i < width; i++ {
+		if !isDigit(value, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// minDigits returns the least digits of a number
+// formatted with n digits, unless exactly n are needed.
+func (f *fields) minDigits(n int, exact bool) int {
+	if f.mode == LenientMode && !exact {
+		return 1
+	}
+	return n
+}
+
+// adjacent reports if items start with a directive.
+func adjacent(items []item) bool {
+	return len(items) > 0 && items[0].spec != 0
+}
+
+// fractionFollows reports if items start
+// with a decimal separator followed by a fractional second directive.
+func fractionFollows(items []item) bool {
+	if len(items) < 2 {
+		return false
+	}
+	if lit := items[0].lit; lit != "." && lit != "," {
+		return false
+	}
+	switch items[1].spec {
+	case 'L', 'f', 'N':
+		return true
+	}
+	return false
+}
+
+// expandYear expands a two-digit year.
+func (f *fields) expandYear(yy int) int {
+	if f.twoDigitYears == nil {
+		return pivotYear(yy)
+	}
+	ref := f.ref
+	if ref.IsZero() {
+		ref = time.Now()
+	}
+	return f.twoDigitYears(yy, ref.Year())
+}
+
+func pivotYear(y int) int {
+	if y >= 69 {
+		return y + 1900
+	}
+	return y + 2000
+}
+
+func isDigit(s string, i int) bool {
+	return i < len(s) && '0' <= s[i] && s[i] <= '9'
+}
+
+const spaces = " \t\n\v\f\r"
+
+// getliteral matches a literal.
+// If lenient, whitespace in the literal matches zero or more whitespace.
+func getliteral(s, lit string, lenient bool) (rest string, ok bool) {
+	if !lenient {
+		if !strings.HasPrefix(s, lit) {
+			return s, false
+		}
+		return s[len(lit):], true
+	}
+	rest = s
+	for i := 0; i < len(lit); i++ {
+		if strings.IndexByte(spaces, lit[i]) >= 0 {
+			rest = strings.TrimLeft(rest, spaces)
+			continue
+		}
+		if !strings.HasPrefix(rest, lit[i:i+1]) {
+			return s, false
+		}
+		rest = rest[1:]
+	}
+	return rest, true
+}
+
+// getnum parses between min and max decimal digits.
+func getnum(s string, min, max int) (n int, rest string, ok bool) {
+	var i int
+	for i = 0; i < max && isDigit(s, i); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	if i < min {
+		return 0, s, false
+	}
+	return n, s[i:], true
+}
+
+// getyear parses an optionally signed year, with at least min digits,
+// or exactly min digits if exact is set.
+// If sign is set, the sign is mandatory.
+func getyear(s string, min int, exact, sign bool) (year int, rest string, ok bool) {
+	neg := strings.HasPrefix(s, "-")
+	if neg || strings.HasPrefix(s, "+") {
+		rest = s[1:]
+	} else if sign {
+		return 0, s, false
+	} else {
+		rest = s
+	}
+
+	max := 10
+	if exact || min > max {
+		max = min
+	}
+	year, rest, ok = getnum(rest, min, max)
+	if !ok {
+		return 0, s, false
+	}
+	if neg {
+		year = -year
+	}
+	return year, rest, true
+}
+
+// getint64 parses an optionally signed decimal integer.
+func getint64(s string) (n int64, rest string, ok bool) {
+	i := 0
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		i++
+	}
+	for isDigit(s, i) {
+		i++
+	}
+	n, err := strconv.ParseInt(s[:i], 10, 64)
+	if err != nil {
+		return 0, s, false
+	}
+	return n, s[i:], true
+}
+
+// getfrac parses an optional fractional second,
+// introduced by a decimal separator.
+func getfrac(s string) (nsec int, rest string) {
+	if len(s) < 2 || s[0] != '.' && s[0] != ',' || !isDigit(s, 1) {
+		return 0, s
+	}
+	i, scale := 1, int(1e9)
+	for ; isDigit(s, i); i++ {
+		if scale > 1 {
+			scale /= 10
+			nsec += int(s[i]-'0') * scale
+		}
+	}
+	return nsec, s[i:]
+}
+
+// getFirstEraYear matches the name of the first year of an era.
+func getFirstEraYear(s string, l *Locale) (rest string, ok bool) {
+	if l.FirstEraYear == "" {
+		return s, false
+	}
+	_, rest, ok = lookup(s, []string{l.FirstEraYear})
+	return rest, ok
+}
+
+// getordinal parses the ordinal suffix of n, ignoring case.
+// If n is negative, the suffix of any day of the month is accepted.
+func getordinal(s string, n int, l *Locale) (rest string, ok bool) {
+	min, max := n, n
+	if n < 0 {
+		min, max = 1, 31
+	}
+	rest = s
+	for n := min; n <= max; n++ {
+		suffix := l.ordinal(n)
+		if len(s) >= len(suffix) && strings.EqualFold(s[:len(suffix)], suffix) &&
+			len(s)-len(suffix) < len(rest) {
+			rest = s[len(suffix):]
+		}
+		ok = ok || suffix == ""
+	}
+	return rest, ok || len(rest) < len(s)
+}
+
+// getoffset parses a time zone offset, as ±hhmm or ±hh:mm.
+func getoffset(s string, colon bool) (offset int, rest string, ok bool) {
+	if len(s) < 1 || s[0] != '+' && s[0] != '-' {
+		return 0, s, false
+	}
+	hh, rest, ok := getnum(s[1:], 2, 2)
+	if !ok {
+		return 0, s, false
+	}
+	if colon {
+		if !strings.HasPrefix(rest, ":") {
+			return 0, s, false
+		}
+		rest = rest[1:]
+	}
+	mm, rest, ok := getnum(rest, 2, 2)
+	if !ok {
+		return 0, s, false
+	}
+	offset = (hh*60 + mm) * 60
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, rest, true
+}
+
+// getzone returns the length of the time zone abbreviation that starts s.
+func getzone(s string) (int, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	if len(s) >= 4 && (s[:4] == "ChST" || s[:4] == "MeST") {
+		return 4, true
+	}
+	if s[:3] == "GMT" {
+		n := 3
+		if len(s) > n && (s[n] == '+' || s[n] == '-') {
+			if _, rest, ok := getnum(s[n+1:], 1, 2); ok {
+				n = len(s) - len(rest)
+			}
+		}
+		return n, true
+	}
+	var n int
+	for n < len(s) && 'A' <= s[n] && s[n] <= 'Z' {
+		n++
+	}
+	switch n {
+	case 3:
+		return n, true
+	case 4, 5:
+		return n, s[n-1] == 'T'
+	}
+	return 0, false
+}
+
+// lookup matches the longest name, ignoring case, that starts s.
+func lookup(s string, names []string) (index int, rest string, ok bool) {
+	index = -1
+	for i, name := range names {
+		if len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) &&
+			(index < 0 || len(name) > len(names[index])) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return 0, s, false
+	}
+	return index, s[len(names[index]):], true
+}
+
+var longDayNames = []string{
+	"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+}
+
+var shortDayNames = []string{
+	"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat",
+}
+
+var longMonthNames = []string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var shortMonthNames = []string{
+	"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+	"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+}
+
+func syntaxError(it item, value string) error {
+	return &time.ParseError{
+		LayoutElem: it.String(),
+		ValueElem:  value,
+	}
+}
+
+type errorString string
+
+func (e errorString) Error() string {
+	return string(e)
+}
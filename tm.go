@@ -0,0 +1,255 @@
+package strftime
+
+import "time"
+
+// A Tm is a broken-down time, as parsed by ParseFields.
+// Set records the fields that were parsed;
+// other fields are zero, or derived from the parsed ones.
+//
+// Fields can be changed before converting a Tm to a time with Time,
+// which normalizes values outside their usual ranges, as C mktime does
+// (e.g. October 32 is November 1).
+type Tm struct {
+	Year       int          // year (%Y), or from %C %y, and era directives
+	Century    int          // year / 100 (%C)
+	Month      int          // month of the year, from 1 (%m %B %b)
+	Day        int          // day of the month, from 1 (%d %e)
+	YearDay    int          // day of the year, from 1 (%j)
+	Weekday    time.Weekday // day of the week (%a %A %u %w)
+	Hour       int          // hour of the day, 0..23 (%H %I with %p)
+	Minute     int          // minute of the hour (%M)
+	Second     int          // second of the minute (%S)
+	Nanosecond int          // nanosecond of the second (%L %f %N)
+
+	SundayWeek     int // week number, weeks starting on Sunday (%U)
+	MondayWeek     int // week number, weeks starting on Monday (%W)
+	ISOWeek        int // ISO 8601 week number (%V)
+	ISOYear        int // ISO 8601 week-based year (%G %g)
+	LocaleWeek     int // locale week number (%:V)
+	LocaleYear     int // locale week-based year (%:G %:g)
+	MonthWeek      int // week of the month (%:W)
+	WeekdayInMonth int // occurrence of the weekday in the month (%:w)
+
+	Unix   int64  // seconds since the Unix epoch (%s %Q)
+	Offset int    // zone offset, in seconds east of UTC (%z)
+	Zone   string // zone abbreviation (%Z)
+
+	Set FieldSet
+
+	parser Parser
+	utc    bool // %z parsed Z
+}
+
+// A FieldSet records the fields of a Tm that were parsed.
+type FieldSet uint32
+
+// Fields of a Tm.
+const (
+	YearField FieldSet = 1 << iota
+	CenturyField
+	MonthField
+	DayField
+	YearDayField
+	WeekdayField
+	HourField
+	MinuteField
+	SecondField
+	NanosecondField
+	SundayWeekField
+	MondayWeekField
+	ISOWeekField
+	ISOYearField
+	LocaleWeekField
+	LocaleYearField
+	MonthWeekField
+	WeekdayInMonthField
+	UnixField
+	OffsetField
+	ZoneField
+)
+
+// The parsed fields of each field of a Tm.
+var tmFieldSets = [...]struct {
+	tm  FieldSet
+	set fieldSet
+}{
+	{YearField, setYear | setYear2 | setCentury | setEra | setYearOfEra | setAltEra},
+	{CenturyField, setCentury},
+	{MonthField, setMonth},
+	{DayField, setDay},
+	{YearDayField, setYearDay},
+	{WeekdayField, setWeekday},
+	{HourField, setHour},
+	{MinuteField, setMinute},
+	{SecondField, setSecond},
+	{NanosecondField, setNanosecond},
+	{SundayWeekField, setSundayWeek},
+	{MondayWeekField, setMondayWeek},
+	{ISOWeekField, setISOWeek},
+	{ISOYearField, setISOYear},
+	{LocaleWeekField, setLocaleWeek},
+	{LocaleYearField, setLocaleYear},
+	{MonthWeekField, setMonthWeek},
+	{WeekdayInMonthField, setWeekdayInMonth},
+	{UnixField, setUnix},
+	{OffsetField, setOffset},
+	{ZoneField, setZone},
+}
+
+// ParseFields is like Parse, but returns the parsed fields,
+// without resolving them to a time.
+func ParseFields(fmt, value string) (*Tm, error) {
+	return defaultLocale.ParseFields(fmt, value)
+}
+
+// ParseFields is like the package level ParseFields, but uses the locale.
+func (l *Locale) ParseFields(fmt, value string) (*Tm, error) {
+	p := Parser{Locale: l}
+	return p.ParseFields(fmt, value)
+}
+
+// ParseFields is like the package level ParseFields, but uses the parser options.
+func (p *Parser) ParseFields(fmt, value string) (*Tm, error) {
+	f, _, err := scan(fmt, value, p, false)
+	if err != nil {
+		return nil, err
+	}
+	tm, err := f.tm(p)
+	if err != nil {
+		return nil, &time.ParseError{
+			Layout:  fmt,
+			Value:   value,
+			Message: ": " + err.Error(),
+		}
+	}
+	return tm, nil
+}
+
+// Has reports if any of the fields were parsed.
+func (tm *Tm) Has(fields FieldSet) bool {
+	return tm.Set&fields != 0
+}
+
+// Time converts the fields to a time.
+// Missing date fields default to January 1 of year 0,
+// missing time fields to midnight, and missing zones to UTC,
+// unless the parser that parsed them has a reference time.
+// Values outside their usual ranges are normalized.
+//
+// Time returns an error if fields not needed to determine the time
+// (e.g. the weekday, when the month and day are set)
+// don't match the time.
+func (tm *Tm) Time() (time.Time, error) {
+	f := tm.fields()
+	f.normalize = true
+	f.validate = true
+	return f.time(tm.parser.locale())
+}
+
+// Fields returns the parsed fields, to format them with FormatFields.
+func (tm *Tm) Fields() Fields {
+	return tmFields{tm}
+}
+
+// tm converts the parsed fields to a Tm.
+func (f *fields) tm(p *Parser) (*Tm, error) {
+	l := p.locale()
+	tm := Tm{
+		Year:           f.getYear(l),
+		Century:        f.century,
+		Month:          f.month,
+		Day:            f.day,
+		YearDay:        f.yday,
+		Weekday:        f.wday,
+		Hour:           f.getHour(),
+		Minute:         f.min,
+		Second:         f.sec,
+		Nanosecond:     f.nsec,
+		SundayWeek:     f.week[sundayWeek],
+		MondayWeek:     f.week[mondayWeek],
+		ISOWeek:        f.week[isoWeek],
+		ISOYear:        f.isoYear,
+		LocaleWeek:     f.week[localeWeek],
+		LocaleYear:     f.wkYear,
+		MonthWeek:      f.week[monthWeek],
+		WeekdayInMonth: f.wdayInMonth,
+		Unix:           f.unix,
+		Offset:         f.offset,
+		Zone:           f.zone,
+		parser:         *p,
+		utc:            f.loc != nil,
+	}
+	if f.monthName != "" {
+		month, err := f.calendarMonth(l.calendar(), tm.Year)
+		if err != nil {
+			return nil, err
+		}
+		tm.Month = month
+	}
+	if f.loc != nil {
+		tm.Set |= OffsetField
+	}
+	for _, t := range tmFieldSets {
+		if f.has(t.set) {
+			tm.Set |= t.tm
+		}
+	}
+	return &tm, nil
+}
+
+// fields converts a Tm to parsed fields.
+func (tm *Tm) fields() fields {
+	f := fields{
+		year:        tm.Year,
+		century:     tm.Century,
+		month:       tm.Month,
+		day:         tm.Day,
+		yday:        tm.YearDay,
+		wday:        tm.Weekday,
+		hour:        tm.Hour,
+		min:         tm.Minute,
+		sec:         tm.Second,
+		nsec:        tm.Nanosecond,
+		isoYear:     tm.ISOYear,
+		wkYear:      tm.LocaleYear,
+		wdayInMonth: tm.WeekdayInMonth,
+		unix:        tm.Unix,
+		offset:      tm.Offset,
+		zone:        tm.Zone,
+		ref:         tm.parser.Reference,
+		closest:     tm.parser.Closest,
+		leapSecond:  tm.parser.LeapSecond,
+	}
+	f.week[sundayWeek] = tm.SundayWeek
+	f.week[mondayWeek] = tm.MondayWeek
+	f.week[isoWeek] = tm.ISOWeek
+	f.week[localeWeek] = tm.LocaleWeek
+	f.week[monthWeek] = tm.MonthWeek
+
+	for _, t := range tmFieldSets {
+		if tm.Has(t.tm) {
+			f.set |= t.set & -t.set // the first of the parsed fields
+		}
+	}
+	if tm.utc && tm.Offset == 0 && tm.Has(OffsetField) {
+		f.loc = time.UTC
+		f.set &^= setOffset
+	}
+	return f
+}
+
+type tmFields struct{ tm *Tm }
+
+func (f tmFields) Year() (int, bool)             { return f.tm.Year, f.tm.Has(YearField) }
+func (f tmFields) Month() (time.Month, bool)     { return time.Month(f.tm.Month), f.tm.Has(MonthField) }
+func (f tmFields) Day() (int, bool)              { return f.tm.Day, f.tm.Has(DayField) }
+func (f tmFields) Hour() (int, bool)             { return f.tm.Hour, f.tm.Has(HourField) }
+func (f tmFields) Minute() (int, bool)           { return f.tm.Minute, f.tm.Has(MinuteField) }
+func (f tmFields) Second() (int, bool)           { return f.tm.Second, f.tm.Has(SecondField) }
+func (f tmFields) Nanosecond() (int, bool)       { return f.tm.Nanosecond, f.tm.Has(NanosecondField) }
+func (f tmFields) Weekday() (time.Weekday, bool) { return f.tm.Weekday, f.tm.Has(WeekdayField) }
+func (f tmFields) YearDay() (int, bool)          { return f.tm.YearDay, f.tm.Has(YearDayField) }
+
+func (f tmFields) Zone() (name string, offset int, ok bool) {
+	return f.tm.Zone, f.tm.Offset, f.tm.Has(OffsetField)
+}
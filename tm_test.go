@@ -0,0 +1,131 @@
+package strftime_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-strftime"
+)
+
+func TestParseFields(t *testing.T) {
+	tm, err := strftime.ParseFields("%b %e %H:%M:%S", "Mar  3 10:00:01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strftime.MonthField | strftime.DayField | strftime.HourField |
+		strftime.MinuteField | strftime.SecondField; tm.Set != want {
+		t.Errorf("Set = %b, want %b", tm.Set, want)
+	}
+	if tm.Has(strftime.YearField | strftime.ZoneField) {
+		t.Error("Has(YearField|ZoneField) = true")
+	}
+	if tm.Month != 3 || tm.Day != 3 || tm.Hour != 10 || tm.Second != 1 {
+		t.Errorf("ParseFields() = %+v", tm)
+	}
+
+	tm.Year = 2024
+	tm.Set |= strftime.YearField
+	if got, err := tm.Time(); err != nil || !got.Equal(time.Date(2024, 3, 3, 10, 0, 1, 0, time.UTC)) {
+		t.Errorf("Time() = (%v, %v)", got, err)
+	}
+
+	tests := []struct {
+		format, value string
+		set           strftime.FieldSet
+	}{
+		{"%C%y", "1999", strftime.YearField | strftime.CenturyField},
+		{"%G-W%V-%u", "2025-W01-1", strftime.ISOYearField | strftime.ISOWeekField | strftime.WeekdayField},
+		{"%Y %U %a", "2024 09 Sun", strftime.YearField | strftime.SundayWeekField | strftime.WeekdayField},
+		{"%Y-%j", "2024-063", strftime.YearField | strftime.YearDayField},
+		{"%s", "1709460001", strftime.UnixField},
+		{"%I %p", "12 AM", strftime.HourField},
+		{"%FT%T%z", "2024-03-03T10:00:01Z", strftime.YearField | strftime.MonthField | strftime.DayField |
+			strftime.HourField | strftime.MinuteField | strftime.SecondField | strftime.OffsetField},
+		{"%FT%T.%L %Z", "2024-03-03T10:00:01.500 CET", strftime.YearField | strftime.MonthField | strftime.DayField |
+			strftime.HourField | strftime.MinuteField | strftime.SecondField | strftime.NanosecondField | strftime.ZoneField},
+	}
+
+	for _, test := range tests {
+		tm, err := strftime.ParseFields(test.format, test.value)
+		if err != nil {
+			t.Errorf("ParseFields(%q, %q) error = %v", test.format, test.value, err)
+			continue
+		}
+		if tm.Set != test.set {
+			t.Errorf("ParseFields(%q, %q).Set = %b, want %b", test.format, test.value, tm.Set, test.set)
+		}
+		want, err := strftime.Parse(test.format, test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := tm.Time(); err != nil || !got.Equal(want) || got.Location().String() != want.Location().String() {
+			t.Errorf("ParseFields(%q, %q).Time() = (%v, %v), want %v", test.format, test.value, got, err, want)
+		}
+	}
+}
+
+func TestTm_Time(t *testing.T) {
+	tests := []struct {
+		format, value string
+		change        func(*strftime.Tm)
+		want          time.Time
+	}{
+		{"%F", "2024-10-31", func(tm *strftime.Tm) { tm.Day++ }, time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"%F", "2024-12-31", func(tm *strftime.Tm) { tm.Month += 2 }, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"%F", "2024-03-01", func(tm *strftime.Tm) { tm.Day = 0 }, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"%F", "2024-01-01", func(tm *strftime.Tm) { tm.Month = 0 }, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{"%F %T", "2024-12-31 23:59:59", func(tm *strftime.Tm) { tm.Second += 2 }, time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC)},
+		{"%Y-%j", "2024-366", func(tm *strftime.Tm) { tm.YearDay += 10 }, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{"%a %F", "Sun 2024-03-03", func(tm *strftime.Tm) { tm.Day += 7 }, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		tm, err := strftime.ParseFields(test.format, test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.change(tm)
+		if got, err := tm.Time(); err != nil || !got.Equal(test.want) {
+			t.Errorf("Time() = (%v, %v), want %v", got, err, test.want)
+		}
+	}
+}
+
+func TestTm_Time_conflicts(t *testing.T) {
+	tests := []struct {
+		format, value string
+		want          string
+	}{
+		{"%a %F", "Mon 2024-03-03", "weekday does not match date"},
+		{"%F %j", "2024-03-03 064", "day-of-year does not match"},
+		{"%F %G-W%V", "2024-03-03 2024-W10", "week does not match date"},
+		{"%F %G", "2024-12-30 2024", "week-based year does not match date"},
+		{"%C %Y", "19 2024", "century does not match year"},
+		{"%s %F", "1709460001 2024-03-04", "date does not match epoch"},
+		{"%s %H", "1709460001 11", "time does not match epoch"},
+	}
+
+	for _, test := range tests {
+		tm, err := strftime.ParseFields(test.format, test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tm.Time(); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("ParseFields(%q, %q).Time() error = %v, want %q", test.format, test.value, err, test.want)
+		}
+	}
+}
+
+func TestTm_Fields(t *testing.T) {
+	tm, err := strftime.ParseFields("%m/%d %H:%M", "03/03 10:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := strftime.FormatFields("%b %-d, %-I:%M %p", tm.Fields()); err != nil || got != "Mar 3, 10:00 AM" {
+		t.Errorf("FormatFields() = (%q, %v)", got, err)
+	}
+	if _, err := strftime.FormatFields("%F", tm.Fields()); err == nil {
+		t.Error("FormatFields() want error")
+	}
+}